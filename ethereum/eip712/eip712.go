@@ -0,0 +1,257 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+// Package eip712 converts Cosmos SDK msgs into the EIP-712 typed-data
+// documents that a plain Ethereum wallet (MetaMask/Ledger-Eth) can sign in
+// place of the usual Cosmos StdSignDoc.
+package eip712
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	ethermint "github.com/evmos/ethermint/types"
+)
+
+const eip712DomainType = "EIP712Domain"
+
+// coinTypeName is the Solidity-style type name registered for every
+// cosmos.base.v1beta1.Coin field encountered while walking a msg.
+const coinTypeName = "Coin"
+
+// feeTypeName and txTypeName are the Solidity-style type names of the
+// envelope wrapper that TxToTypedData signs over, on top of the msg's own
+// type.
+const (
+	feeTypeName = "Fee"
+	txTypeName  = "Tx"
+)
+
+// TxData carries the tx envelope fields that must be committed to by an
+// EIP-712 signature alongside the msg itself: without them, a
+// (typedData, signature) pair signed for one fee/memo/account would recover
+// identically for any other, since only the msg's own fields would be
+// hashed.
+type TxData struct {
+	AccountNumber uint64
+	Sequence      uint64
+	Fee           sdk.Coins
+	GasLimit      uint64
+	Memo          string
+}
+
+// MsgToTypedData builds the EIP-712 typed-data document for msg alone, with
+// no fee/memo/account binding. It is kept for callers that only need the
+// msg's own typed-data tree (e.g. to compute PrimaryType/Types for a single
+// message); tx signing must go through TxToTypedData instead so the signed
+// hash commits to what actually gets broadcast.
+func MsgToTypedData(chainID string, msg sdk.Msg) (apitypes.TypedData, error) {
+	chainIDInt, types, typeName, message, err := msgTypedData(chainID, msg, apitypes.Types{})
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	return apitypes.TypedData{
+		Types:       types,
+		PrimaryType: typeName,
+		Domain: apitypes.TypedDataDomain{
+			Name:    "Ethermint",
+			Version: "1.0.0",
+			ChainId: (*math.HexOrDecimal256)(chainIDInt),
+		},
+		Message: message,
+	}, nil
+}
+
+// TxToTypedData builds the EIP-712 typed-data document for a single-msg tx,
+// wrapping msg's own typed-data tree in a "Tx" envelope that also carries
+// txData's fee, gas limit, memo, account number and sequence. Binding the
+// signature to the full envelope, not just the msg, is what stops a
+// (typedData, signature) pair obtained for one fee/memo/account/sequence
+// from being rebroadcast with another.
+func TxToTypedData(chainID string, msg sdk.Msg, txData TxData) (apitypes.TypedData, error) {
+	types := apitypes.Types{
+		feeTypeName: {
+			{Name: "amount", Type: "string"},
+			{Name: "gas_limit", Type: "uint256"},
+		},
+	}
+
+	chainIDInt, types, msgTypeName, msgValue, err := msgTypedData(chainID, msg, types)
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	types[txTypeName] = []apitypes.Type{
+		{Name: "account_number", Type: "uint256"},
+		{Name: "sequence", Type: "uint256"},
+		{Name: "fee", Type: feeTypeName},
+		{Name: "memo", Type: "string"},
+		{Name: "msg", Type: msgTypeName},
+	}
+
+	message := apitypes.TypedDataMessage{
+		"account_number": new(big.Int).SetUint64(txData.AccountNumber),
+		"sequence":       new(big.Int).SetUint64(txData.Sequence),
+		"memo":           txData.Memo,
+		"fee": apitypes.TypedDataMessage{
+			"amount":    txData.Fee.String(),
+			"gas_limit": new(big.Int).SetUint64(txData.GasLimit),
+		},
+		"msg": msgValue,
+	}
+
+	return apitypes.TypedData{
+		Types:       types,
+		PrimaryType: txTypeName,
+		Domain: apitypes.TypedDataDomain{
+			Name:    "Ethermint",
+			Version: "1.0.0",
+			ChainId: (*math.HexOrDecimal256)(chainIDInt),
+		},
+		Message: message,
+	}, nil
+}
+
+// msgTypedData registers msg's own type (and any types it nests) into
+// types and returns its parsed chain ID, type name and value tree, shared
+// by MsgToTypedData and TxToTypedData.
+func msgTypedData(chainID string, msg sdk.Msg, types apitypes.Types) (*big.Int, apitypes.Types, string, apitypes.TypedDataMessage, error) {
+	protoMsg, ok := msg.(proto.Message)
+	if !ok {
+		return nil, nil, "", nil, fmt.Errorf("msg %T does not implement proto.Message", msg)
+	}
+
+	chainIDInt, err := ethermint.ParseChainID(chainID)
+	if err != nil {
+		return nil, nil, "", nil, fmt.Errorf("failed to parse eip-155 chain id from %q: %w", chainID, err)
+	}
+
+	types[eip712DomainType] = []apitypes.Type{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	}
+
+	reflectMsg := protoMsg.ProtoReflect()
+	typeName := string(reflectMsg.Descriptor().Name())
+
+	message, err := messageTypeAndValue(reflectMsg, typeName, types)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+
+	return chainIDInt, types, typeName, message, nil
+}
+
+// TypedDataHash computes the EIP-712 hash of typedData: keccak256(0x19 0x01
+// || domainSeparator || hashStruct(message)).
+func TypedDataHash(typedData apitypes.TypedData) (common.Hash, error) {
+	domainSeparator, err := typedData.HashStruct(eip712DomainType, typedData.Domain.Map())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash EIP712Domain: %w", err)
+	}
+
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash %s: %w", typedData.PrimaryType, err)
+	}
+
+	rawData := append([]byte{0x19, 0x01}, domainSeparator...)
+	rawData = append(rawData, messageHash...)
+	return crypto.Keccak256Hash(rawData), nil
+}
+
+// messageTypeAndValue registers typeName's fields in types and returns its
+// Message value tree, recursing into nested message fields.
+func messageTypeAndValue(m protoreflect.Message, typeName string, types apitypes.Types) (apitypes.TypedDataMessage, error) {
+	fields := m.Descriptor().Fields()
+	typeFields := make([]apitypes.Type, 0, fields.Len())
+	value := apitypes.TypedDataMessage{}
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		solType, fieldValue, err := fieldTypeAndValue(m, fd, types)
+		if err != nil {
+			return nil, fmt.Errorf("field %q of %s: %w", fd.Name(), typeName, err)
+		}
+
+		typeFields = append(typeFields, apitypes.Type{Name: string(fd.Name()), Type: solType})
+		value[string(fd.Name())] = fieldValue
+	}
+
+	if _, registered := types[typeName]; !registered {
+		types[typeName] = typeFields
+	}
+	return value, nil
+}
+
+// fieldTypeAndValue returns the Solidity-style type name and the value for a
+// single field, recursing for nested messages.
+func fieldTypeAndValue(m protoreflect.Message, fd protoreflect.FieldDescriptor, types apitypes.Types) (string, interface{}, error) {
+	if fd.IsList() || fd.IsMap() {
+		return "", nil, fmt.Errorf("repeated/map fields are not supported yet")
+	}
+
+	v := m.Get(fd)
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return "string", v.String(), nil
+	case protoreflect.BoolKind:
+		return "bool", v.Bool(), nil
+	case protoreflect.BytesKind:
+		return "bytes", v.Bytes(), nil
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return "int256", big.NewInt(v.Int()), nil
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return "uint256", new(big.Int).SetUint64(v.Uint()), nil
+	case protoreflect.MessageKind:
+		nested := v.Message()
+		switch nested.Descriptor().FullName() {
+		case "cosmos.base.v1beta1.Coin":
+			if _, registered := types[coinTypeName]; !registered {
+				types[coinTypeName] = []apitypes.Type{
+					{Name: "denom", Type: "string"},
+					{Name: "amount", Type: "string"},
+				}
+			}
+			fields := nested.Descriptor().Fields()
+			return coinTypeName, apitypes.TypedDataMessage{
+				"denom":  nested.Get(fields.ByName("denom")).String(),
+				"amount": nested.Get(fields.ByName("amount")).String(),
+			}, nil
+		default:
+			nestedTypeName := string(nested.Descriptor().Name())
+			nestedValue, err := messageTypeAndValue(nested, nestedTypeName, types)
+			if err != nil {
+				return "", nil, err
+			}
+			return nestedTypeName, nestedValue, nil
+		}
+	default:
+		return "", nil, fmt.Errorf("unsupported protobuf kind %s", fd.Kind())
+	}
+}
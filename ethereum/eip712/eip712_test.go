@@ -0,0 +1,122 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package eip712_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/ethermint/ethereum/eip712"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+const testChainID = "ethermint_9000-1"
+
+// TestMsgToTypedDataSignRoundTrip signs the produced typed-data hash with
+// go-ethereum's crypto.Sign and checks that it recovers to the EVM address
+// that originally produced the signature, the same check x/evm/ante does.
+func TestMsgToTypedDataSignRoundTrip(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	evmAddress := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	msg := types.NewMsgSetMappingEvmAddress("orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv", evmAddress.Hex())
+
+	typedData, err := eip712.MsgToTypedData(testChainID, &msg)
+	require.NoError(t, err)
+
+	hash, err := eip712.TypedDataHash(typedData)
+	require.NoError(t, err)
+
+	sig, err := crypto.Sign(hash.Bytes(), privKey)
+	require.NoError(t, err)
+
+	recoveredPubKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	require.NoError(t, err)
+	require.Equal(t, evmAddress, crypto.PubkeyToAddress(*recoveredPubKey))
+}
+
+// TestTypedDataHashIsDeterministic checks that rebuilding the typed data for
+// the same msg - as the ante decorator does to re-derive the hash from a
+// broadcast tx - always yields the same hash.
+func TestTypedDataHashIsDeterministic(t *testing.T) {
+	msg := types.NewMsgDeleteMappingEvmAddress("orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv")
+
+	first, err := eip712.MsgToTypedData(testChainID, &msg)
+	require.NoError(t, err)
+	second, err := eip712.MsgToTypedData(testChainID, &msg)
+	require.NoError(t, err)
+
+	firstHash, err := eip712.TypedDataHash(first)
+	require.NoError(t, err)
+	secondHash, err := eip712.TypedDataHash(second)
+	require.NoError(t, err)
+
+	require.Equal(t, firstHash, secondHash)
+}
+
+// TestTypedDataHashDiffersPerSigner checks that two distinct msgs hash
+// differently, guarding against a generator that accidentally ignores field
+// values.
+func TestTypedDataHashDiffersPerSigner(t *testing.T) {
+	msgA := types.NewMsgDeleteMappingEvmAddress("orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv")
+	msgB := types.NewMsgDeleteMappingEvmAddress("orai1p5yxut8sv2ceqar2c9gf9rhjesd2qj5d939kkc")
+
+	typedDataA, err := eip712.MsgToTypedData(testChainID, &msgA)
+	require.NoError(t, err)
+	typedDataB, err := eip712.MsgToTypedData(testChainID, &msgB)
+	require.NoError(t, err)
+
+	hashA, err := eip712.TypedDataHash(typedDataA)
+	require.NoError(t, err)
+	hashB, err := eip712.TypedDataHash(typedDataB)
+	require.NoError(t, err)
+
+	require.NotEqual(t, hashA, hashB)
+}
+
+// TestTxToTypedDataHashDiffersPerEnvelope checks that two txs carrying the
+// same msg but a different fee/memo/account/sequence hash differently, so a
+// (typedData, signature) pair signed for one envelope can't be replayed
+// with another.
+func TestTxToTypedDataHashDiffersPerEnvelope(t *testing.T) {
+	msg := types.NewMsgDeleteMappingEvmAddress("orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv")
+
+	base := eip712.TxData{
+		AccountNumber: 7,
+		Sequence:      3,
+		Fee:           sdk.NewCoins(sdk.NewInt64Coin("uorai", 1000)),
+		GasLimit:      200000,
+		Memo:          "base memo",
+	}
+	tampered := base
+	tampered.Fee = sdk.NewCoins(sdk.NewInt64Coin("uorai", 1000000))
+
+	baseTypedData, err := eip712.TxToTypedData(testChainID, &msg, base)
+	require.NoError(t, err)
+	tamperedTypedData, err := eip712.TxToTypedData(testChainID, &msg, tampered)
+	require.NoError(t, err)
+
+	baseHash, err := eip712.TypedDataHash(baseTypedData)
+	require.NoError(t, err)
+	tamperedHash, err := eip712.TypedDataHash(tamperedTypedData)
+	require.NoError(t, err)
+
+	require.NotEqual(t, baseHash, tamperedHash)
+}
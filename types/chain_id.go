@@ -0,0 +1,42 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+// chainIDRegex matches a cosmos chain-id of the form "identifier_eip155ChainID-version",
+// e.g. "ethermint_9000-1".
+var chainIDRegex = regexp.MustCompile(`^[a-z0-9]+_{1}([0-9]+)-{1}([0-9]+)$`)
+
+// ParseChainID parses a string chain identifier's EIP-155 chain ID portion,
+// returning an error if the chain-id doesn't match the expected format.
+func ParseChainID(chainID string) (*big.Int, error) {
+	matches := chainIDRegex.FindStringSubmatch(chainID)
+	if matches == nil || len(matches) < 2 {
+		return nil, fmt.Errorf("invalid chain-id: %s, must be of the form <identifier>_<eip155-chain-id>-<version>", chainID)
+	}
+
+	chainIDInt, ok := new(big.Int).SetString(matches[1], 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid eip155 chain-id portion of %s: %s", chainID, matches[1])
+	}
+
+	return chainIDInt, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package types
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	proto "github.com/cosmos/gogoproto/proto"
+)
+
+// ExtensionOptionsWeb3Tx is carried as a Cosmos tx extension option by txs
+// signed as EIP-712 typed data instead of a StdSignDoc, so a plain Ethereum
+// wallet (MetaMask/Ledger-Eth) can sign a Cosmos msg. TypedDataHash/Signature
+// stand in for verification of the tx's usual signatures field, which still
+// carries one SignatureV2 that is not itself verified; x/evm/ante verifies
+// Signature recovers to the signer's mapped EVM address over TypedDataHash.
+//
+// Declared by hand here, with protobuf struct tags, rather than generated by
+// protoc.
+type ExtensionOptionsWeb3Tx struct {
+	// typed_data_hash is the hex-encoded EIP-712 hash the signature was produced over.
+	TypedDataHash string `protobuf:"bytes,1,opt,name=typed_data_hash,json=typedDataHash,proto3" json:"typed_data_hash,omitempty"`
+	// signature is the hex-encoded secp256k1 signature over typed_data_hash.
+	Signature string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *ExtensionOptionsWeb3Tx) Reset()         { *m = ExtensionOptionsWeb3Tx{} }
+func (m *ExtensionOptionsWeb3Tx) String() string { return proto.CompactTextString(m) }
+func (*ExtensionOptionsWeb3Tx) ProtoMessage()    {}
+
+// RegisterInterfaces registers ExtensionOptionsWeb3Tx against
+// sdktx.TxExtensionOptionI, the same marker interface
+// ExtensionOptionsEthereumTx registers against upstream. Without this, a
+// codectypes.Any built in-process (e.g. via codectypes.NewAnyWithValue)
+// carries a cached value, but an Any decoded off the wire - the case that
+// matters for a tx actually broadcast and checked by a validator - has
+// nothing to resolve its TypeUrl to, so UnpackInterfaces leaves
+// GetCachedValue nil and extractWeb3Tx never sees it.
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations(
+		(*sdktx.TxExtensionOptionI)(nil),
+		&ExtensionOptionsWeb3Tx{},
+	)
+}
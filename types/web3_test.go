@@ -0,0 +1,71 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package types_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/ethermint/types"
+)
+
+// TestExtensionOptionsWeb3TxResolvesAfterWireRoundTrip proves
+// ExtensionOptionsWeb3Tx resolves its cached value the way a validator sees
+// it - decoded off the wire - not just when built in-process. An Any that
+// skips the marshal/unmarshal round trip keeps the cached value it was
+// constructed with regardless of registration, which is why that shortcut
+// can't be used to test this.
+func TestExtensionOptionsWeb3TxResolvesAfterWireRoundTrip(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	types.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	want := &types.ExtensionOptionsWeb3Tx{TypedDataHash: "0xabc", Signature: "0xdef"}
+	any, err := codectypes.NewAnyWithValue(want)
+	require.NoError(t, err)
+
+	bz, err := cdc.Marshal(any)
+	require.NoError(t, err)
+
+	wireAny := &codectypes.Any{}
+	require.NoError(t, cdc.Unmarshal(bz, wireAny))
+	require.Nil(t, wireAny.GetCachedValue(), "a freshly unmarshaled Any has no cached value until UnpackInterfaces resolves it")
+
+	var resolved sdktx.TxExtensionOptionI
+	require.NoError(t, registry.UnpackAny(wireAny, &resolved))
+	require.Equal(t, want, resolved)
+}
+
+func TestRegisterInterfacesRejectsUnregisteredExtensionOption(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	cdc := codec.NewProtoCodec(registry)
+
+	any, err := codectypes.NewAnyWithValue(&types.ExtensionOptionsWeb3Tx{TypedDataHash: "0xabc"})
+	require.NoError(t, err)
+
+	bz, err := cdc.Marshal(any)
+	require.NoError(t, err)
+
+	wireAny := &codectypes.Any{}
+	require.NoError(t, cdc.Unmarshal(bz, wireAny))
+
+	var resolved sdktx.TxExtensionOptionI
+	require.Error(t, registry.UnpackAny(wireAny, &resolved), "without RegisterInterfaces the registry has nothing to resolve the TypeUrl to")
+}
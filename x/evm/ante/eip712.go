@@ -0,0 +1,236 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package ante
+
+import (
+	"strings"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/ethermint/ethereum/eip712"
+	ethermint "github.com/evmos/ethermint/types"
+)
+
+// ExtensionOptionsTxI is implemented by txs that can carry Cosmos extension
+// options, i.e. the tx builder's wire type. It lets this decorator look for
+// an ExtensionOptionsWeb3Tx without depending on a concrete tx type.
+type ExtensionOptionsTxI interface {
+	GetExtensionOptions() []*codectypes.Any
+}
+
+// SigVerifiableTxI is implemented by txs that expose their SignatureV2s,
+// i.e. the tx builder's wire type. It lets this decorator read the
+// signer's sequence without depending on x/auth/signing.
+type SigVerifiableTxI interface {
+	GetSignaturesV2() ([]signing.SignatureV2, error)
+}
+
+// EvmKeeper is the subset of the evm keeper this decorator needs to resolve
+// a signer's mapped EVM address.
+type EvmKeeper interface {
+	GetMappedEvmAddress(ctx sdk.Context, cosmosAddress string) (string, bool)
+}
+
+// AccountI is the subset of the auth module's AccountI this decorator needs
+// to bind an EIP-712 signature to the signer's current account number.
+type AccountI interface {
+	GetAccountNumber() uint64
+}
+
+// AccountKeeper is the subset of the auth keeper this decorator needs to
+// resolve a signer's account.
+type AccountKeeper interface {
+	GetAccount(ctx sdk.Context, addr sdk.AccAddress) AccountI
+}
+
+// Eip712WebTxDecorator verifies txs carrying an ExtensionOptionsWeb3Tx: it
+// rebuilds the EIP-712 typed-data hash for the tx's single msg plus its fee,
+// gas limit, memo, account number and sequence, and checks that the carried
+// secp256k1 signature recovers to the EVM address mapped to the msg's
+// signer, so a plain Ethereum wallet (MetaMask/Ledger-Eth) can sign a Cosmos
+// tx without producing a StdSignDoc. Binding the hash to the full envelope,
+// not just the msg, is what stops a (typedData, signature) pair handed off
+// for broadcast from being reassembled with a different fee/gas/memo.
+// Txs without the extension option are passed through unchanged.
+//
+// A web3 tx carries no standard secp256k1 signature, so the default
+// SigVerificationDecorator must never run against one - not just run after
+// this decorator, but be skipped entirely. Use NewWeb3AwareAnteHandler to
+// build an AnteHandler that does that; this decorator's AnteHandle never
+// invokes SigVerificationDecorator itself, it only authenticates and calls
+// next.
+type Eip712WebTxDecorator struct {
+	evmKeeper     EvmKeeper
+	accountKeeper AccountKeeper
+}
+
+// NewEip712WebTxDecorator returns a new Eip712WebTxDecorator.
+func NewEip712WebTxDecorator(evmKeeper EvmKeeper, accountKeeper AccountKeeper) Eip712WebTxDecorator {
+	return Eip712WebTxDecorator{evmKeeper: evmKeeper, accountKeeper: accountKeeper}
+}
+
+func (d Eip712WebTxDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, error) {
+	web3Tx, err := extractWeb3Tx(tx)
+	if err != nil {
+		return ctx, err
+	}
+	if web3Tx == nil {
+		return next(ctx, tx, simulate)
+	}
+
+	msgs := tx.GetMsgs()
+	if len(msgs) != 1 {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "eip-712 web3 txs must carry exactly one msg")
+	}
+	msg := msgs[0]
+
+	signer, ok := msg.(interface{ GetSigners() []sdk.AccAddress })
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "eip-712 msg does not declare a signer")
+	}
+	signers := signer.GetSigners()
+	if len(signers) != 1 {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "eip-712 web3 txs must have exactly one signer")
+	}
+
+	txData, err := d.txData(ctx, tx, signers[0])
+	if err != nil {
+		return ctx, err
+	}
+
+	typedData, err := eip712.TxToTypedData(ctx.ChainID(), msg, txData)
+	if err != nil {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "failed to rebuild eip-712 typed data: "+err.Error())
+	}
+
+	hash, err := eip712.TypedDataHash(typedData)
+	if err != nil {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "failed to hash eip-712 typed data: "+err.Error())
+	}
+	if !strings.EqualFold(hash.Hex(), web3Tx.TypedDataHash) {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "eip-712 typed data hash mismatch")
+	}
+
+	sigBytes, err := hexutil.Decode(web3Tx.Signature)
+	if err != nil {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "invalid eip-712 signature encoding")
+	}
+
+	recoveredPubKey, err := crypto.SigToPub(hash.Bytes(), sigBytes)
+	if err != nil {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "failed to recover eip-712 signer")
+	}
+	recoveredAddr := crypto.PubkeyToAddress(*recoveredPubKey)
+
+	mappedEvmAddress, found := d.evmKeeper.GetMappedEvmAddress(ctx, signers[0].String())
+	if !found || !strings.EqualFold(mappedEvmAddress, recoveredAddr.Hex()) {
+		return ctx, sdkerrors.Wrap(
+			sdkerrors.ErrUnauthorized,
+			"eip-712 signature does not recover to the signer's mapped evm address",
+		)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// txData reads the fee, gas limit, memo, account number and sequence off
+// tx/signer so they can be folded into the EIP-712 typed data alongside the
+// msg itself.
+func (d Eip712WebTxDecorator) txData(ctx sdk.Context, tx sdk.Tx, signer sdk.AccAddress) (eip712.TxData, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return eip712.TxData{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "eip-712 web3 txs must carry a fee")
+	}
+
+	memoTx, ok := tx.(sdk.TxWithMemo)
+	if !ok {
+		return eip712.TxData{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "eip-712 web3 txs must carry a memo")
+	}
+
+	sigTx, ok := tx.(SigVerifiableTxI)
+	if !ok {
+		return eip712.TxData{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "eip-712 web3 txs must carry signature info")
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return eip712.TxData{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "failed to read eip-712 tx signatures: "+err.Error())
+	}
+	if len(sigs) != 1 {
+		return eip712.TxData{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "eip-712 web3 txs must carry exactly one signature")
+	}
+
+	account := d.accountKeeper.GetAccount(ctx, signer)
+	if account == nil {
+		return eip712.TxData{}, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "eip-712 signer %s has no account", signer)
+	}
+
+	return eip712.TxData{
+		AccountNumber: account.GetAccountNumber(),
+		Sequence:      sigs[0].Sequence,
+		Fee:           feeTx.GetFee(),
+		GasLimit:      feeTx.GetGas(),
+		Memo:          memoTx.GetMemo(),
+	}, nil
+}
+
+// extractWeb3Tx returns tx's ExtensionOptionsWeb3Tx, or nil if it doesn't
+// carry one.
+func extractWeb3Tx(tx sdk.Tx) (*ethermint.ExtensionOptionsWeb3Tx, error) {
+	extTx, ok := tx.(ExtensionOptionsTxI)
+	if !ok {
+		return nil, nil
+	}
+
+	for _, any := range extTx.GetExtensionOptions() {
+		web3Tx, ok := any.GetCachedValue().(*ethermint.ExtensionOptionsWeb3Tx)
+		if ok {
+			return web3Tx, nil
+		}
+	}
+	return nil, nil
+}
+
+// NewWeb3AwareAnteHandler returns the AnteHandler the app should install in
+// place of the chain's normal SigVerificationDecorator step: for a tx
+// carrying an ExtensionOptionsWeb3Tx, it runs decorator and, once that
+// authenticates the EIP-712 signature, goes straight to postSigHandler -
+// sigVerificationHandler never runs, since a web3 tx has no standard
+// signature for it to check. Any other tx runs sigVerificationHandler then
+// postSigHandler, same as if decorator weren't in the chain at all.
+func NewWeb3AwareAnteHandler(decorator Eip712WebTxDecorator, sigVerificationHandler, postSigHandler sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		web3Tx, err := extractWeb3Tx(tx)
+		if err != nil {
+			return ctx, err
+		}
+		if web3Tx == nil {
+			ctx, err := sigVerificationHandler(ctx, tx, simulate)
+			if err != nil {
+				return ctx, err
+			}
+			return postSigHandler(ctx, tx, simulate)
+		}
+
+		return decorator.AnteHandle(ctx, tx, simulate, postSigHandler)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package ante_test
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/ethermint/ethereum/eip712"
+	ethermint "github.com/evmos/ethermint/types"
+	"github.com/evmos/ethermint/x/evm/ante"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+const testChainID = "ethermint_9000-1"
+
+// fakeTxData is the fee/gas/memo/account/sequence envelope newWeb3Tx signs
+// every test tx over, matching what a real TxBuilder-produced tx carries.
+var fakeTxData = eip712.TxData{
+	AccountNumber: 7,
+	Sequence:      3,
+	Fee:           sdk.NewCoins(sdk.NewInt64Coin("uorai", 1000)),
+	GasLimit:      200000,
+	Memo:          "test memo",
+}
+
+// fakeTx is a minimal sdk.Tx + ante.ExtensionOptionsTxI, + the sdk.FeeTx /
+// sdk.TxWithMemo / ante.SigVerifiableTxI interfaces the decorator reads the
+// envelope from, carrying a single msg and extension option - enough to
+// drive Eip712WebTxDecorator without a full TxBuilder/TxConfig harness.
+type fakeTx struct {
+	msg      sdk.Msg
+	exts     []*codectypes.Any
+	txData   eip712.TxData
+	sequence uint64
+}
+
+func (t fakeTx) GetMsgs() []sdk.Msg                     { return []sdk.Msg{t.msg} }
+func (t fakeTx) ValidateBasic() error                   { return nil }
+func (t fakeTx) GetExtensionOptions() []*codectypes.Any { return t.exts }
+func (t fakeTx) GetGas() uint64                         { return t.txData.GasLimit }
+func (t fakeTx) GetFee() sdk.Coins                      { return t.txData.Fee }
+func (t fakeTx) FeePayer() sdk.AccAddress               { return nil }
+func (t fakeTx) FeeGranter() sdk.AccAddress             { return nil }
+func (t fakeTx) GetMemo() string                        { return t.txData.Memo }
+
+func (t fakeTx) GetSignaturesV2() ([]signing.SignatureV2, error) {
+	return []signing.SignatureV2{{Sequence: t.txData.Sequence}}, nil
+}
+
+// fakeEvmKeeper is a hand-rolled stub of ante.EvmKeeper, since the real evm
+// keeper lives outside this module's trimmed slice.
+type fakeEvmKeeper struct {
+	mapping map[string]string
+}
+
+func (k fakeEvmKeeper) GetMappedEvmAddress(_ sdk.Context, cosmosAddress string) (string, bool) {
+	evmAddress, found := k.mapping[cosmosAddress]
+	return evmAddress, found
+}
+
+// fakeAccount is a hand-rolled stub of ante.AccountI.
+type fakeAccount struct {
+	accountNumber uint64
+}
+
+func (a fakeAccount) GetAccountNumber() uint64 { return a.accountNumber }
+
+// fakeAccountKeeper is a hand-rolled stub of ante.AccountKeeper, since the
+// real auth keeper lives outside this module's trimmed slice.
+type fakeAccountKeeper struct {
+	accountNumbers map[string]uint64
+}
+
+func (k fakeAccountKeeper) GetAccount(_ sdk.Context, addr sdk.AccAddress) ante.AccountI {
+	accountNumber, found := k.accountNumbers[addr.String()]
+	if !found {
+		return nil
+	}
+	return fakeAccount{accountNumber: accountNumber}
+}
+
+func newWeb3Tx(t *testing.T, msg sdk.Msg, txData eip712.TxData, sign func(hash []byte) []byte) fakeTx {
+	t.Helper()
+
+	typedData, err := eip712.TxToTypedData(testChainID, msg, txData)
+	require.NoError(t, err)
+	hash, err := eip712.TypedDataHash(typedData)
+	require.NoError(t, err)
+
+	sig := sign(hash.Bytes())
+
+	any, err := codectypes.NewAnyWithValue(&ethermint.ExtensionOptionsWeb3Tx{
+		TypedDataHash: hash.Hex(),
+		Signature:     hexutil.Encode(sig),
+	})
+	require.NoError(t, err)
+
+	return fakeTx{msg: msg, exts: []*codectypes.Any{any}, txData: txData}
+}
+
+func TestEip712WebTxDecoratorAcceptsValidSignature(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	evmAddress := crypto.PubkeyToAddress(privKey.PublicKey)
+	cosmosAddress := "orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv"
+
+	msg := types.NewMsgDeleteMappingEvmAddress(cosmosAddress)
+	tx := newWeb3Tx(t, &msg, fakeTxData, func(hash []byte) []byte {
+		sig, err := crypto.Sign(hash, privKey)
+		require.NoError(t, err)
+		return sig
+	})
+
+	decorator := ante.NewEip712WebTxDecorator(
+		fakeEvmKeeper{mapping: map[string]string{cosmosAddress: evmAddress.Hex()}},
+		fakeAccountKeeper{accountNumbers: map[string]uint64{cosmosAddress: fakeTxData.AccountNumber}},
+	)
+
+	called := false
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		called = true
+		return ctx, nil
+	}
+
+	_, err = decorator.AnteHandle(sdk.Context{}, tx, false, next)
+	require.NoError(t, err)
+	require.True(t, called, "next should run once the eip-712 signature is verified")
+}
+
+func TestEip712WebTxDecoratorRejectsWrongSigner(t *testing.T) {
+	signerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	mappedKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	cosmosAddress := "orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv"
+
+	msg := types.NewMsgDeleteMappingEvmAddress(cosmosAddress)
+	tx := newWeb3Tx(t, &msg, fakeTxData, func(hash []byte) []byte {
+		sig, err := crypto.Sign(hash, signerKey)
+		require.NoError(t, err)
+		return sig
+	})
+
+	decorator := ante.NewEip712WebTxDecorator(
+		fakeEvmKeeper{mapping: map[string]string{cosmosAddress: crypto.PubkeyToAddress(mappedKey.PublicKey).Hex()}},
+		fakeAccountKeeper{accountNumbers: map[string]uint64{cosmosAddress: fakeTxData.AccountNumber}},
+	)
+
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		t.Fatal("next should not run when the eip-712 signature doesn't match the mapped evm address")
+		return ctx, nil
+	}
+
+	_, err = decorator.AnteHandle(sdk.Context{}, tx, false, next)
+	require.Error(t, err)
+}
+
+// TestEip712WebTxDecoratorRejectsTamperedFee signs over fakeTxData but the
+// broadcast tx swaps in a higher fee afterwards; the ante decorator must
+// reject it since the signed hash no longer matches the tx envelope. This
+// guards the fix for a signature that only committed to the bare msg.
+func TestEip712WebTxDecoratorRejectsTamperedFee(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	evmAddress := crypto.PubkeyToAddress(privKey.PublicKey)
+	cosmosAddress := "orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv"
+
+	msg := types.NewMsgDeleteMappingEvmAddress(cosmosAddress)
+	tx := newWeb3Tx(t, &msg, fakeTxData, func(hash []byte) []byte {
+		sig, err := crypto.Sign(hash, privKey)
+		require.NoError(t, err)
+		return sig
+	})
+
+	tamperedTxData := fakeTxData
+	tamperedTxData.Fee = sdk.NewCoins(sdk.NewInt64Coin("uorai", 1000000))
+	tx.txData = tamperedTxData
+
+	decorator := ante.NewEip712WebTxDecorator(
+		fakeEvmKeeper{mapping: map[string]string{cosmosAddress: evmAddress.Hex()}},
+		fakeAccountKeeper{accountNumbers: map[string]uint64{cosmosAddress: fakeTxData.AccountNumber}},
+	)
+
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		t.Fatal("next should not run once the broadcast fee no longer matches the signed typed data")
+		return ctx, nil
+	}
+
+	_, err = decorator.AnteHandle(sdk.Context{}, tx, false, next)
+	require.Error(t, err)
+}
+
+func TestEip712WebTxDecoratorPassesThroughPlainTxs(t *testing.T) {
+	msg := types.NewMsgDeleteMappingEvmAddress("orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv")
+	tx := fakeTx{msg: &msg}
+
+	decorator := ante.NewEip712WebTxDecorator(fakeEvmKeeper{}, fakeAccountKeeper{})
+
+	called := false
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		called = true
+		return ctx, nil
+	}
+
+	_, err := decorator.AnteHandle(sdk.Context{}, tx, false, next)
+	require.NoError(t, err)
+	require.True(t, called, "txs without the web3 extension option should be passed through unchanged")
+}
+
+// TestNewWeb3AwareAnteHandlerSkipsSigVerificationForWeb3Tx is the regression
+// test for wiring a web3 tx around sigVerificationHandler entirely: a web3
+// tx carries no standard secp256k1 signature, so a stand-in
+// sigVerificationHandler that always errors must never run for one, while
+// postSigHandler still does.
+func TestNewWeb3AwareAnteHandlerSkipsSigVerificationForWeb3Tx(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	evmAddress := crypto.PubkeyToAddress(privKey.PublicKey)
+	cosmosAddress := "orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv"
+
+	msg := types.NewMsgDeleteMappingEvmAddress(cosmosAddress)
+	tx := newWeb3Tx(t, &msg, fakeTxData, func(hash []byte) []byte {
+		sig, err := crypto.Sign(hash, privKey)
+		require.NoError(t, err)
+		return sig
+	})
+
+	decorator := ante.NewEip712WebTxDecorator(
+		fakeEvmKeeper{mapping: map[string]string{cosmosAddress: evmAddress.Hex()}},
+		fakeAccountKeeper{accountNumbers: map[string]uint64{cosmosAddress: fakeTxData.AccountNumber}},
+	)
+
+	sigVerificationHandler := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		t.Fatal("sigVerificationHandler must not run for a tx carrying an eip-712 web3 signature")
+		return ctx, nil
+	}
+	postSigRan := false
+	postSigHandler := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		postSigRan = true
+		return ctx, nil
+	}
+
+	handler := ante.NewWeb3AwareAnteHandler(decorator, sigVerificationHandler, postSigHandler)
+	_, err = handler(sdk.Context{}, tx, false)
+	require.NoError(t, err)
+	require.True(t, postSigRan, "postSigHandler should still run once the eip-712 signature is verified")
+}
+
+// TestNewWeb3AwareAnteHandlerRunsSigVerificationForPlainTx checks the other
+// side of the branch: a tx without the web3 extension option runs
+// sigVerificationHandler before postSigHandler, same as if
+// Eip712WebTxDecorator weren't in the chain at all.
+func TestNewWeb3AwareAnteHandlerRunsSigVerificationForPlainTx(t *testing.T) {
+	msg := types.NewMsgDeleteMappingEvmAddress("orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv")
+	tx := fakeTx{msg: &msg}
+
+	decorator := ante.NewEip712WebTxDecorator(fakeEvmKeeper{}, fakeAccountKeeper{})
+
+	var order []string
+	sigVerificationHandler := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		order = append(order, "sig")
+		return ctx, nil
+	}
+	postSigHandler := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		order = append(order, "postSig")
+		return ctx, nil
+	}
+
+	handler := ante.NewWeb3AwareAnteHandler(decorator, sigVerificationHandler, postSigHandler)
+	_, err := handler(sdk.Context{}, tx, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"sig", "postSig"}, order)
+}
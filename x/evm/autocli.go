@@ -0,0 +1,128 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package evm
+
+import (
+	"fmt"
+
+	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+
+	"github.com/cosmos/cosmos-sdk/version"
+
+	evmv1 "github.com/evmos/ethermint/api/ethermint/evm/v1"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// AutoCLIOptions implements the autocli.HasAutoCLIConfig interface, replacing
+// the imperative builders in x/evm/client/cli with generated query and tx
+// commands. `raw` is intentionally left out since it does binary Ethereum
+// tx decoding and stays hand-written in NewRawTxCmd.
+func (am AppModule) AutoCLIOptions() *autocliv1.ModuleOptions {
+	return &autocliv1.ModuleOptions{
+		Query: &autocliv1.ServiceCommandDescriptor{
+			Service: evmv1.Query_ServiceDesc.ServiceName,
+			RpcCommandOptions: []*autocliv1.RpcCommandOptions{
+				{
+					RpcMethod: "Storage",
+					Use:       "storage ADDRESS KEY",
+					Short:     "Gets storage for an account with a given key and height",
+					Long:      "Gets storage for an account with a given key and height. If the height is not provided, it will use the latest height from context.", //nolint:lll
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "address"},
+						{ProtoField: "key"},
+					},
+				},
+				{
+					RpcMethod: "Code",
+					Use:       "code ADDRESS",
+					Short:     "Gets code from an account",
+					Long:      "Gets code from an account. If the height is not provided, it will use the latest height from context.",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "address"},
+					},
+				},
+				{
+					RpcMethod: "Params",
+					Use:       "params",
+					Short:     "Gets evm module params",
+				},
+				{
+					RpcMethod: "MappedEvmAddress",
+					Use:       "mappedevm COSMOS_ADDRESS",
+					Short:     "Query the evm mapped evm address given a cosmos address",
+					Example: fmt.Sprintf(
+						"%[1]s q %[2]s mappedevm orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv",
+						version.AppName, types.ModuleName,
+					),
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "cosmos_address"},
+					},
+				},
+				{
+					RpcMethod: "MappedCosmosAddress",
+					Use:       "mapped-cosmos EVM_ADDRESS",
+					Short:     "Query the cosmos address mapped to a given evm address",
+					Example: fmt.Sprintf(
+						"%[1]s q %[2]s mapped-cosmos 0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E",
+						version.AppName, types.ModuleName,
+					),
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "evm_address"},
+					},
+				},
+				{
+					RpcMethod: "MappedEvmAddresses",
+					Use:       "list-mappings",
+					Short:     "Query all cosmos/evm address mappings",
+					Example: fmt.Sprintf(
+						"%[1]s q %[2]s list-mappings --limit 100",
+						version.AppName, types.ModuleName,
+					),
+				},
+			},
+		},
+		Tx: &autocliv1.ServiceCommandDescriptor{
+			Service: evmv1.Msg_ServiceDesc.ServiceName,
+			RpcCommandOptions: []*autocliv1.RpcCommandOptions{
+				{
+					RpcMethod: "SetMappingEvmAddress",
+					Use:       "set-mapping-evm EVM_ADDRESS",
+					Short:     "Set a mapping EVM address for the sender cosmos address",
+					Example: fmt.Sprintf(`
+%[1]s tx %[2]s set-mapping-evm AvSl0d9JrHCW4mdEyHvZu076WxLgH0bBVLigUcFm4UjV --from <key> --gas 1000000
+`, version.AppName, types.ModuleName,
+					),
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+						{ProtoField: "evm_address"},
+					},
+				},
+				{
+					RpcMethod: "DeleteMappingEvmAddress",
+					Use:       "delete-mapping-evm",
+					Short:     "Delete a mapping EVM address for the sender cosmos address",
+					Example: fmt.Sprintf(`
+%[1]s tx %[2]s delete-mapping-evm --from <key> --gas 1000000
+`, version.AppName, types.ModuleName,
+					),
+				},
+				{
+					RpcMethod: "EthereumTx",
+					Skip:      true, // hand-written in NewRawTxCmd: it does binary Ethereum tx decoding
+				},
+			},
+		},
+	}
+}
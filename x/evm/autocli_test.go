@@ -0,0 +1,88 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package evm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/ethermint/x/evm"
+	"github.com/evmos/ethermint/x/evm/client/cli"
+)
+
+// legacyCoveredQueryRpcs/legacyCoveredTxRpcs list the RPCs that, per the
+// Deprecated comments on GetQueryCmd/GetTxCmd, are served by both a
+// hand-written command and an AutoCLIOptions entry during the deprecation
+// window. They must be kept in sync by hand: removing one side without the
+// other is exactly the drift this test exists to catch.
+var (
+	legacyCoveredQueryRpcs = []string{"Storage", "Code", "Params", "MappedEvmAddress", "MappedCosmosAddress", "MappedEvmAddresses"}
+	legacyCoveredTxRpcs    = []string{"SetMappingEvmAddress", "DeleteMappingEvmAddress"}
+)
+
+func TestAutoCLIQueryOptionsCoverDeprecatedLegacyCommands(t *testing.T) {
+	opts := evm.AppModule{}.AutoCLIOptions()
+
+	rpcMethods := make(map[string]bool, len(opts.Query.RpcCommandOptions))
+	for _, opt := range opts.Query.RpcCommandOptions {
+		rpcMethods[opt.RpcMethod] = true
+	}
+
+	for _, rpc := range legacyCoveredQueryRpcs {
+		require.True(t, rpcMethods[rpc], "AutoCLIOptions.Query is missing an entry for %s, "+
+			"which GetQueryCmd's deprecation-window comment says is still generated via AutoCLI", rpc)
+	}
+}
+
+func TestAutoCLITxOptionsCoverDeprecatedLegacyCommands(t *testing.T) {
+	opts := evm.AppModule{}.AutoCLIOptions()
+
+	rpcMethods := make(map[string]bool, len(opts.Tx.RpcCommandOptions))
+	for _, opt := range opts.Tx.RpcCommandOptions {
+		rpcMethods[opt.RpcMethod] = true
+	}
+
+	for _, rpc := range legacyCoveredTxRpcs {
+		require.True(t, rpcMethods[rpc], "AutoCLIOptions.Tx is missing an entry for %s, "+
+			"which GetTxCmd's deprecation-window comment says is still generated via AutoCLI", rpc)
+	}
+}
+
+func TestGetQueryCmdRegistersEveryAutoCLICoveredCommand(t *testing.T) {
+	legacyUses := map[string]bool{}
+	for _, sub := range cli.GetQueryCmd().Commands() {
+		legacyUses[sub.Name()] = true
+	}
+
+	// Use names as registered by GetStorageCmd/GetCodeCmd/.../QueryMappedEvmAddressesCmd.
+	wantUses := []string{"storage", "code", "params", "mappedevm", "mapped-cosmos", "list-mappings"}
+	for _, use := range wantUses {
+		require.True(t, legacyUses[use], "GetQueryCmd lost its %q subcommand while an AutoCLI entry for it still exists", use)
+	}
+}
+
+func TestGetTxCmdRegistersEveryAutoCLICoveredCommand(t *testing.T) {
+	legacyUses := map[string]bool{}
+	for _, sub := range cli.GetTxCmd().Commands() {
+		legacyUses[sub.Name()] = true
+	}
+
+	wantUses := []string{"set-mapping-evm", "delete-mapping-evm"}
+	for _, use := range wantUses {
+		require.True(t, legacyUses[use], "GetTxCmd lost its %q subcommand while an AutoCLI entry for it still exists", use)
+	}
+}
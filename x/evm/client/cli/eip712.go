@@ -0,0 +1,166 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/evmos/ethermint/ethereum/eip712"
+	ethermint "github.com/evmos/ethermint/types"
+)
+
+const (
+	flagEIP712    = "eip712"
+	flagSignature = "signature"
+)
+
+// addEIP712Flags wires the --eip712 and --signature flags shared by the
+// mapping-evm tx commands onto cmd.
+func addEIP712Flags(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagEIP712, false, "sign the tx as EIP-712 typed data so it can be signed by an Ethereum wallet")
+	cmd.Flags().String(flagSignature, "", "the 0x-prefixed secp256k1 signature over the EIP-712 typed data produced with --eip712 --generate-only")
+}
+
+// generateOrBroadcastEIP712TxCLI handles the --eip712 signing path for the
+// mapping-evm msgs: with --generate-only it prints the EIP-712 typed-data
+// JSON for external signing, and with --signature it assembles and
+// broadcasts the tx carrying an ExtensionOptionsWeb3Tx so that AnteHandler
+// can verify it against the derived EVM address. The tx is built through
+// the same clienttx.Factory the non-EIP-712 path uses so that fee, gas and
+// memo come from the usual --fees/--gas/--gas-prices/--memo flags instead
+// of being silently dropped, and so the typed data signs over the same
+// envelope that gets broadcast.
+func generateOrBroadcastEIP712TxCLI(clientCtx client.Context, cmd *cobra.Command, msg sdk.Msg) error {
+	txf, err := clienttx.NewFactoryCLI(clientCtx, cmd.Flags()).Prepare(clientCtx)
+	if err != nil {
+		return errors.Wrap(err, "failed to prepare eip-712 tx factory")
+	}
+
+	txBuilder, err := txf.BuildUnsignedTx(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to build eip-712 tx")
+	}
+
+	feeTx, ok := txBuilder.GetTx().(sdk.FeeTx)
+	if !ok {
+		return errors.New("eip-712 tx builder does not expose fee info")
+	}
+	memoTx, ok := txBuilder.GetTx().(sdk.TxWithMemo)
+	if !ok {
+		return errors.New("eip-712 tx builder does not expose memo info")
+	}
+
+	typedData, err := eip712.TxToTypedData(clientCtx.ChainID, msg, eip712.TxData{
+		AccountNumber: txf.AccountNumber(),
+		Sequence:      txf.Sequence(),
+		Fee:           feeTx.GetFee(),
+		GasLimit:      feeTx.GetGas(),
+		Memo:          memoTx.GetMemo(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to build EIP-712 typed data")
+	}
+
+	if clientCtx.GenerateOnly {
+		out, err := json.MarshalIndent(typedData, "", "  ")
+		if err != nil {
+			return err
+		}
+		return clientCtx.PrintString(fmt.Sprintf("%s\n", out))
+	}
+
+	sigHex, err := cmd.Flags().GetString(flagSignature)
+	if err != nil {
+		return err
+	}
+	if sigHex == "" {
+		return errors.New("--signature is required to broadcast an --eip712 tx; run again with --generate-only to obtain the typed data to sign")
+	}
+
+	sigBytes, err := hexutil.Decode(sigHex)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode --signature")
+	}
+
+	typedDataHash, err := eip712.TypedDataHash(typedData)
+	if err != nil {
+		return errors.Wrap(err, "failed to hash EIP-712 typed data")
+	}
+
+	ext, err := types.NewAnyWithValue(&ethermint.ExtensionOptionsWeb3Tx{
+		TypedDataHash: typedDataHash.Hex(),
+		Signature:     sigHex,
+	})
+	if err != nil {
+		return err
+	}
+
+	builder, ok := txBuilder.(txtypes.ExtensionOptionsTxBuilder)
+	if !ok {
+		return errors.New("current tx builder does not support extension options")
+	}
+	builder.SetExtensionOptions(ext)
+
+	// x/evm/ante.Eip712WebTxDecorator is what actually authorizes a web3 tx,
+	// by re-deriving typedDataHash (over the msg, fee, gas, memo, account
+	// number and this same sequence) and checking that sigBytes recovers to
+	// the signer's mapped EVM address. This SignatureV2 only keeps the tx's
+	// standard signatures field non-empty so SignerInfo-dependent plumbing
+	// (account/fee decorators, tx introspection) keeps working; its PubKey
+	// is not itself re-verified by the ante chain.
+	keyRecord, err := clientCtx.Keyring.Key(clientCtx.GetFromName())
+	if err != nil {
+		return errors.Wrap(err, "failed to load signing key for --eip712 tx")
+	}
+	pubKey, err := keyRecord.GetPubKey()
+	if err != nil {
+		return err
+	}
+
+	if err := txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey: pubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode:  txf.SignMode(),
+			Signature: sigBytes,
+		},
+		Sequence: txf.Sequence(),
+	}); err != nil {
+		return err
+	}
+
+	txBytes, err := clientCtx.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return err
+	}
+
+	res, err := clientCtx.BroadcastTx(txBytes)
+	if err != nil {
+		return err
+	}
+
+	return clientCtx.PrintProto(res)
+}
@@ -29,7 +29,14 @@ import (
 	"github.com/evmos/ethermint/x/evm/types"
 )
 
+const flagPending = "pending"
+
 // GetQueryCmd returns the parent command for all x/bank CLi query commands.
+//
+// Deprecated: storage, code, params, mappedevm, mapped-cosmos and
+// list-mappings are now generated via AutoCLIOptions in x/evm/autocli.go;
+// this builder keeps them only for the deprecation window. balance, nonce
+// and logs have no AutoCLI entry and are registered only here.
 func GetQueryCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -44,6 +51,11 @@ func GetQueryCmd() *cobra.Command {
 		GetCodeCmd(),
 		GetParamsCmd(),
 		QueryMappedEvmAddressCmd(),
+		QueryMappedCosmosAddressCmd(),
+		QueryMappedEvmAddressesCmd(),
+		GetBalanceCmd(),
+		GetNonceCmd(),
+		GetLogsCmd(),
 	)
 	return cmd
 }
@@ -125,6 +137,91 @@ func GetCodeCmd() *cobra.Command {
 	return cmd
 }
 
+// GetBalanceCmd queries the evm balance of a given address directly from the
+// evm keeper's statedb, without going through the JSON-RPC layer.
+func GetBalanceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "balance ADDRESS",
+		Short: "Gets the evm balance of an account",
+		Long:  "Gets the evm balance of an account. If the height is not provided, it will use the latest height from context.", //nolint:lll
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			address, err := accountToHex(args[0])
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryBalanceRequest{
+				Address: address,
+			}
+
+			res, err := queryClient.Balance(rpctypes.ContextWithHeight(clientCtx.Height), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetNonceCmd queries the evm nonce of a given address directly from the evm
+// keeper's statedb, without going through the JSON-RPC layer.
+func GetNonceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nonce ADDRESS",
+		Short: "Gets the evm nonce of an account",
+		Long: "Gets the evm nonce of an account. If the height is not provided, it will use the latest height " +
+			"from context. --pending is accepted but the nonce is always read from committed state; " +
+			"transactions still in the mempool are not counted.", //nolint:lll
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			address, err := accountToHex(args[0])
+			if err != nil {
+				return err
+			}
+
+			pending, err := cmd.Flags().GetBool(flagPending)
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryNonceRequest{
+				Address: address,
+				Pending: pending,
+			}
+
+			res, err := queryClient.Nonce(rpctypes.ContextWithHeight(clientCtx.Height), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().Bool(flagPending, false, "accepted for compatibility; the nonce is read from committed state and mempool transactions are not counted")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetParamsCmd queries the code field of a given address
 func GetParamsCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -182,4 +279,73 @@ func QueryMappedEvmAddressCmd() *cobra.Command {
 	}
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
-}
\ No newline at end of file
+}
+
+// QueryMappedCosmosAddressCmd queries the cosmos address mapped to a given evm address
+func QueryMappedCosmosAddressCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mapped-cosmos EVM_ADDRESS",
+		Short: "Query the cosmos address mapped to a given evm address",
+		Example: fmt.Sprintf(
+			"%[1]s q %[2]s mapped-cosmos 0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E",
+			version.AppName, types.ModuleName,
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			evmAddress, err := accountToHex(args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.MappedCosmosAddress(context.Background(), &types.QueryMappedCosmosAddressRequest{EvmAddress: evmAddress})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// QueryMappedEvmAddressesCmd queries all cosmos/evm address mappings with pagination
+func QueryMappedEvmAddressesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-mappings",
+		Short: "Query all cosmos/evm address mappings",
+		Example: fmt.Sprintf(
+			"%[1]s q %[2]s list-mappings --limit 100",
+			version.AppName, types.ModuleName,
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.MappedEvmAddresses(context.Background(), &types.QueryMappedEvmAddressesRequest{Pagination: pageReq})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddPaginationFlagsToCmd(cmd, "mappings")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
@@ -0,0 +1,301 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+const (
+	flagRawFile           = "file"
+	flagRawStdin          = "stdin"
+	flagRawDryRun         = "dry-run"
+	flagRawParallelDecode = "parallel-decode"
+
+	// maxRawBatchSize bounds how many MsgEthereumTxs can be bundled into a
+	// single cosmos tx via the raw command.
+	maxRawBatchSize = 100
+)
+
+// NewRawTxCmd command builds a cosmos transaction from one or more raw
+// ethereum transactions, given as a positional hex string, one hex-encoded
+// tx per line via --file, or one per line on stdin via --stdin.
+func NewRawTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "raw [tx-hex]",
+		Short: "Build cosmos transaction from one or more raw ethereum transactions",
+		Long: "Build cosmos transaction from one or more raw ethereum transactions, given either as a positional hex " +
+			"string, one per line via --file, or one per line on stdin via --stdin. Up to 100 decoded transactions " +
+			"are bundled into a single cosmos tx, preserving the order they were given in.", //nolint:lll
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rawTxs, err := collectRawTxHexes(cmd, args)
+			if err != nil {
+				return err
+			}
+			if len(rawTxs) == 0 {
+				return errors.New("no raw ethereum transactions given: pass TX_HEX, --file or --stdin")
+			}
+			if err := checkBatchSize(len(rawTxs)); err != nil {
+				return err
+			}
+
+			parallelDecode, err := cmd.Flags().GetBool(flagRawParallelDecode)
+			if err != nil {
+				return err
+			}
+
+			msgs, err := decodeRawTxs(rawTxs, parallelDecode)
+			if err != nil {
+				return err
+			}
+
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			rsp, err := rpctypes.NewQueryClient(clientCtx).Params(cmd.Context(), &types.QueryParamsRequest{})
+			if err != nil {
+				return err
+			}
+
+			sdkMsgs := make([]sdk.Msg, len(msgs))
+			totalFee := sdk.NewCoins()
+			var totalGas uint64
+			for i, msg := range msgs {
+				builtTx, err := msg.BuildTx(clientCtx.TxConfig.NewTxBuilder(), rsp.Params.EvmDenom)
+				if err != nil {
+					return errors.Wrapf(err, "failed to build tx for raw transaction %d", i)
+				}
+
+				totalFee = totalFee.Add(builtTx.GetFee()...)
+				totalGas += builtTx.GetGas()
+				sdkMsgs[i] = msg
+			}
+
+			dryRun, err := cmd.Flags().GetBool(flagRawDryRun)
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				for i, msg := range msgs {
+					gas, err := intrinsicGas(msg)
+					if err != nil {
+						return errors.Wrapf(err, "failed to compute intrinsic gas for raw transaction %d", i)
+					}
+					fmt.Fprintf(os.Stdout, "tx %d: intrinsic_gas=%d gas_limit=%d\n", i, gas, msg.GetGas())
+				}
+				fmt.Fprintf(os.Stdout, "total fee: %s\n", totalFee)
+				return nil
+			}
+
+			txBuilder := clientCtx.TxConfig.NewTxBuilder()
+			if err := txBuilder.SetMsgs(sdkMsgs...); err != nil {
+				return err
+			}
+			txBuilder.SetFeeAmount(totalFee)
+			txBuilder.SetGasLimit(totalGas)
+			builtTx := txBuilder.GetTx()
+
+			if clientCtx.GenerateOnly {
+				json, err := clientCtx.TxConfig.TxJSONEncoder()(builtTx)
+				if err != nil {
+					return err
+				}
+
+				return clientCtx.PrintString(fmt.Sprintf("%s\n", json))
+			}
+
+			if !clientCtx.SkipConfirm {
+				out, err := clientCtx.TxConfig.TxJSONEncoder()(builtTx)
+				if err != nil {
+					return err
+				}
+
+				_, _ = fmt.Fprintf(os.Stderr, "%s\n\n", out)
+
+				buf := bufio.NewReader(os.Stdin)
+				ok, err := input.GetConfirmation("confirm transaction before signing and broadcasting", buf, os.Stderr)
+
+				if err != nil || !ok {
+					_, _ = fmt.Fprintf(os.Stderr, "%s\n", "canceled transaction")
+					return err
+				}
+			}
+
+			txBytes, err := clientCtx.TxConfig.TxEncoder()(builtTx)
+			if err != nil {
+				return err
+			}
+
+			// broadcast to a Tendermint node
+			res, err := clientCtx.BroadcastTx(txBytes)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	cmd.Flags().String(flagRawFile, "", "path to a file with one hex-encoded ethereum tx per line")
+	cmd.Flags().Bool(flagRawStdin, false, "read one hex-encoded ethereum tx per line from stdin")
+	cmd.Flags().Bool(flagRawDryRun, false, "print each tx's intrinsic gas and the batch's total fee, without confirming or broadcasting")
+	cmd.Flags().Bool(flagRawParallelDecode, false, "decode and validate the batch concurrently")
+	return cmd
+}
+
+// checkBatchSize returns an error if n raw ethereum txs would exceed
+// maxRawBatchSize.
+func checkBatchSize(n int) error {
+	if n > maxRawBatchSize {
+		return errors.Errorf("%d raw transactions given, batches are limited to %d", n, maxRawBatchSize)
+	}
+	return nil
+}
+
+// collectRawTxHexes gathers the hex-encoded ethereum txs to decode from the
+// positional argument, --file or --stdin, in that precedence order.
+func collectRawTxHexes(cmd *cobra.Command, args []string) ([]string, error) {
+	if len(args) == 1 {
+		return []string{args[0]}, nil
+	}
+
+	file, err := cmd.Flags().GetString(flagRawFile)
+	if err != nil {
+		return nil, err
+	}
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open --file")
+		}
+		defer f.Close()
+
+		return scanRawTxHexes(f)
+	}
+
+	useStdin, err := cmd.Flags().GetBool(flagRawStdin)
+	if err != nil {
+		return nil, err
+	}
+	if useStdin {
+		return scanRawTxHexes(os.Stdin)
+	}
+
+	return nil, nil
+}
+
+func scanRawTxHexes(r *os.File) ([]string, error) {
+	var rawTxs []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		rawTxs = append(rawTxs, line)
+	}
+	return rawTxs, scanner.Err()
+}
+
+// intrinsicGas computes a tx's intrinsic gas the same way go-ethereum's
+// state transition does: a flat per-tx base cost (higher for contract
+// creation), plus a per-byte calldata cost, plus access-list costs for
+// EIP-2930/1559 txs. isEIP3860 is passed as false since this CLI has no way
+// to learn whether the evm module's active params are Shanghai or later, so
+// initcode-size metering is conservatively left out of the estimate.
+func intrinsicGas(msg *types.MsgEthereumTx) (uint64, error) {
+	ethTx := msg.AsTransaction()
+	return core.IntrinsicGas(ethTx.Data(), ethTx.AccessList(), ethTx.To() == nil, true, true, false)
+}
+
+// decodeRawTxs decodes and validates a batch of hex-encoded ethereum txs,
+// preserving their original order. When parallelDecode is set the batch is
+// decoded concurrently, which is worthwhile for large batches.
+func decodeRawTxs(rawTxs []string, parallelDecode bool) ([]*types.MsgEthereumTx, error) {
+	msgs := make([]*types.MsgEthereumTx, len(rawTxs))
+
+	decodeOne := func(i int) error {
+		data, err := hexutil.Decode(rawTxs[i])
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode ethereum tx hex bytes at index %d", i)
+		}
+
+		msg := &types.MsgEthereumTx{}
+		if err := msg.UnmarshalBinary(data); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal ethereum tx at index %d", i)
+		}
+
+		if err := msg.ValidateBasic(); err != nil {
+			return errors.Wrapf(err, "invalid ethereum tx at index %d", i)
+		}
+
+		msgs[i] = msg
+		return nil
+	}
+
+	if !parallelDecode {
+		for i := range rawTxs {
+			if err := decodeOne(i); err != nil {
+				return nil, err
+			}
+		}
+		return msgs, nil
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i := range rawTxs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := decodeOne(i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return msgs, nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package cli
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+// newRawTxFlagsCmd returns a bare *cobra.Command carrying just the --file
+// and --stdin flags collectRawTxHexes reads, so collectRawTxHexes can be
+// driven without the full NewRawTxCmd (which needs a live client context).
+func newRawTxFlagsCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "raw"}
+	cmd.Flags().String(flagRawFile, "", "")
+	cmd.Flags().Bool(flagRawStdin, false, "")
+	return cmd
+}
+
+// newSignedRawHex signs tx for chainID with a freshly generated key and
+// returns its hex-encoded binary encoding, ready to feed into a raw tx
+// command the same way collectRawTxHexes would.
+func newSignedRawHex(t *testing.T, tx *ethtypes.Transaction, chainID *big.Int) string {
+	t.Helper()
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signer := ethtypes.LatestSignerForChainID(chainID)
+	signedTx, err := ethtypes.SignTx(tx, signer, privKey)
+	require.NoError(t, err)
+
+	data, err := signedTx.MarshalBinary()
+	require.NoError(t, err)
+
+	return hexutil.Encode(data)
+}
+
+func TestIntrinsicGasMatchesCoreIntrinsicGasAcrossTxTypes(t *testing.T) {
+	chainID := big.NewInt(9000)
+	to := common.HexToAddress("0x0000000000000000000000000000000000dEaD")
+	callData := []byte{0x00, 0x00, 0x01, 0x02, 0x00, 0xff}
+
+	cases := map[string]*ethtypes.Transaction{
+		"legacy": ethtypes.NewTx(&ethtypes.LegacyTx{
+			Nonce: 0, To: &to, Value: big.NewInt(0), Gas: 100_000, GasPrice: big.NewInt(1), Data: callData,
+		}),
+		"dynamic-fee": ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID: chainID, Nonce: 0, To: &to, Value: big.NewInt(0), Gas: 100_000,
+			GasFeeCap: big.NewInt(2), GasTipCap: big.NewInt(1), Data: callData,
+		}),
+		"access-list": ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID: chainID, Nonce: 0, To: &to, Value: big.NewInt(0), Gas: 100_000, GasPrice: big.NewInt(1), Data: callData,
+			AccessList: ethtypes.AccessList{{
+				Address:     to,
+				StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")},
+			}},
+		}),
+		"contract-creation": ethtypes.NewTx(&ethtypes.LegacyTx{
+			Nonce: 0, To: nil, Value: big.NewInt(0), Gas: 1_000_000, GasPrice: big.NewInt(1), Data: callData,
+		}),
+	}
+
+	for name, tx := range cases {
+		tx := tx
+		t.Run(name, func(t *testing.T) {
+			rawHex := newSignedRawHex(t, tx, chainID)
+
+			msgs, err := decodeRawTxs([]string{rawHex}, false)
+			require.NoError(t, err)
+			require.Len(t, msgs, 1)
+
+			got, err := intrinsicGas(msgs[0])
+			require.NoError(t, err)
+
+			want, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, true, false)
+			require.NoError(t, err)
+
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestIntrinsicGasIsHigherForContractCreation(t *testing.T) {
+	chainID := big.NewInt(9000)
+	to := common.HexToAddress("0x0000000000000000000000000000000000dEaD")
+
+	call := ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: 0, To: &to, Value: big.NewInt(0), Gas: 100_000, GasPrice: big.NewInt(1)})
+	create := ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: 0, To: nil, Value: big.NewInt(0), Gas: 100_000, GasPrice: big.NewInt(1)})
+
+	callMsgs, err := decodeRawTxs([]string{newSignedRawHex(t, call, chainID)}, false)
+	require.NoError(t, err)
+	createMsgs, err := decodeRawTxs([]string{newSignedRawHex(t, create, chainID)}, false)
+	require.NoError(t, err)
+
+	callGas, err := intrinsicGas(callMsgs[0])
+	require.NoError(t, err)
+	createGas, err := intrinsicGas(createMsgs[0])
+	require.NoError(t, err)
+
+	require.Greater(t, createGas, callGas, "contract creation pays a higher base intrinsic gas than a call")
+}
+
+// newMixedBatchRawHexes returns the hex-encoded batch - one legacy, one
+// EIP-1559 dynamic-fee and one EIP-2930 access-list tx, in that order - that
+// the batch-handling tests below feed through collectRawTxHexes/decodeRawTxs
+// together, the way NewRawTxCmd bundles a --file/--stdin batch.
+func newMixedBatchRawHexes(t *testing.T) []string {
+	t.Helper()
+
+	chainID := big.NewInt(9000)
+	to := common.HexToAddress("0x0000000000000000000000000000000000dEaD")
+
+	legacy := ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce: 0, To: &to, Value: big.NewInt(0), Gas: 100_000, GasPrice: big.NewInt(1),
+	})
+	dynamicFee := ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+		ChainID: chainID, Nonce: 1, To: &to, Value: big.NewInt(0), Gas: 100_000,
+		GasFeeCap: big.NewInt(2), GasTipCap: big.NewInt(1),
+	})
+	accessList := ethtypes.NewTx(&ethtypes.AccessListTx{
+		ChainID: chainID, Nonce: 2, To: &to, Value: big.NewInt(0), Gas: 100_000, GasPrice: big.NewInt(1),
+		AccessList: ethtypes.AccessList{{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01")}}},
+	})
+
+	return []string{
+		newSignedRawHex(t, legacy, chainID),
+		newSignedRawHex(t, dynamicFee, chainID),
+		newSignedRawHex(t, accessList, chainID),
+	}
+}
+
+// TestDecodeRawTxsMixedBatchPreservesOrder checks that a single batch mixing
+// legacy, EIP-1559 and EIP-2930 txs decodes every tx and keeps them in their
+// original order, regardless of whether decoding runs sequentially or via
+// --parallel-decode.
+func TestDecodeRawTxsMixedBatchPreservesOrder(t *testing.T) {
+	rawHexes := newMixedBatchRawHexes(t)
+
+	for name, parallelDecode := range map[string]bool{"sequential": false, "parallel-decode": true} {
+		parallelDecode := parallelDecode
+		t.Run(name, func(t *testing.T) {
+			msgs, err := decodeRawTxs(rawHexes, parallelDecode)
+			require.NoError(t, err)
+			require.Len(t, msgs, 3)
+
+			for i, msg := range msgs {
+				require.Equal(t, uint64(i), msg.AsTransaction().Nonce(), "batch order must be preserved")
+			}
+			require.Equal(t, uint8(ethtypes.LegacyTxType), msgs[0].AsTransaction().Type())
+			require.Equal(t, uint8(ethtypes.DynamicFeeTxType), msgs[1].AsTransaction().Type())
+			require.Equal(t, uint8(ethtypes.AccessListTxType), msgs[2].AsTransaction().Type())
+		})
+	}
+}
+
+// TestDecodeRawTxsParallelDecodeMatchesSequential checks --parallel-decode
+// yields the same decoded batch as sequential decoding for a mixed batch.
+func TestDecodeRawTxsParallelDecodeMatchesSequential(t *testing.T) {
+	rawHexes := newMixedBatchRawHexes(t)
+
+	sequential, err := decodeRawTxs(rawHexes, false)
+	require.NoError(t, err)
+	parallel, err := decodeRawTxs(rawHexes, true)
+	require.NoError(t, err)
+
+	require.Len(t, parallel, len(sequential))
+	for i := range sequential {
+		require.Equal(t, sequential[i].AsTransaction().Hash(), parallel[i].AsTransaction().Hash())
+	}
+}
+
+func TestCheckBatchSizeRejectsOverCap(t *testing.T) {
+	require.NoError(t, checkBatchSize(maxRawBatchSize))
+	require.Error(t, checkBatchSize(maxRawBatchSize+1))
+}
+
+func TestCollectRawTxHexesPositionalArgTakesPrecedence(t *testing.T) {
+	cmd := newRawTxFlagsCmd()
+	require.NoError(t, cmd.Flags().Set(flagRawFile, "/should/not/be/read"))
+
+	rawTxs, err := collectRawTxHexes(cmd, []string{"0xdeadbeef"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"0xdeadbeef"}, rawTxs)
+}
+
+// TestCollectRawTxHexesFromFile checks --file is read one hex tx per line,
+// in file order, with blank lines skipped - the --file side of the batch
+// feature the raw command exists for.
+func TestCollectRawTxHexesFromFile(t *testing.T) {
+	rawHexes := newMixedBatchRawHexes(t)
+
+	path := filepath.Join(t.TempDir(), "raw-txs.txt")
+	content := rawHexes[0] + "\n\n" + rawHexes[1] + "\n" + rawHexes[2] + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	cmd := newRawTxFlagsCmd()
+	require.NoError(t, cmd.Flags().Set(flagRawFile, path))
+
+	rawTxs, err := collectRawTxHexes(cmd, nil)
+	require.NoError(t, err)
+	require.Equal(t, rawHexes, rawTxs, "blank lines must be skipped and order preserved")
+}
+
+func TestCollectRawTxHexesNoSourceGivenReturnsEmpty(t *testing.T) {
+	cmd := newRawTxFlagsCmd()
+
+	rawTxs, err := collectRawTxHexes(cmd, nil)
+	require.NoError(t, err)
+	require.Empty(t, rawTxs)
+}
+
+// TestScanRawTxHexesSkipsBlankLines exercises scanRawTxHexes directly,
+// since it is the shared scanning logic behind both --file and --stdin (the
+// latter just passes os.Stdin instead of an opened file).
+func TestScanRawTxHexesSkipsBlankLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "raw-txs.txt")
+	require.NoError(t, os.WriteFile(path, []byte("0xaaaa\n\n0xbbbb\n"), 0o600))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	rawTxs, err := scanRawTxHexes(f)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0xaaaa", "0xbbbb"}, rawTxs)
+}
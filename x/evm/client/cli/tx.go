@@ -16,24 +16,24 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
-	"github.com/cosmos/cosmos-sdk/client/input"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/version"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
-	rpctypes "github.com/evmos/ethermint/rpc/types"
 	"github.com/evmos/ethermint/x/evm/types"
 )
 
 // GetTxCmd returns the transaction commands for this module
+//
+// Deprecated: set-mapping-evm and delete-mapping-evm are now generated via
+// AutoCLIOptions in x/evm/autocli.go. raw stays hand-written since it does
+// binary Ethereum decoding. This builder is kept only for the deprecation
+// window and should be removed once downstream tooling has migrated to the
+// generated commands.
 func GetTxCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -50,93 +50,13 @@ func GetTxCmd() *cobra.Command {
 	return cmd
 }
 
-// NewRawTxCmd command build cosmos transaction from raw ethereum transaction
-func NewRawTxCmd() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "raw TX_HEX",
-		Short: "Build cosmos transaction from raw ethereum transaction",
-		Args:  cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			data, err := hexutil.Decode(args[0])
-			if err != nil {
-				return errors.Wrap(err, "failed to decode ethereum tx hex bytes")
-			}
-
-			msg := &types.MsgEthereumTx{}
-			if err := msg.UnmarshalBinary(data); err != nil {
-				return err
-			}
-
-			if err := msg.ValidateBasic(); err != nil {
-				return err
-			}
-
-			clientCtx, err := client.GetClientTxContext(cmd)
-			if err != nil {
-				return err
-			}
-
-			rsp, err := rpctypes.NewQueryClient(clientCtx).Params(cmd.Context(), &types.QueryParamsRequest{})
-			if err != nil {
-				return err
-			}
-
-			tx, err := msg.BuildTx(clientCtx.TxConfig.NewTxBuilder(), rsp.Params.EvmDenom)
-			if err != nil {
-				return err
-			}
-
-			if clientCtx.GenerateOnly {
-				json, err := clientCtx.TxConfig.TxJSONEncoder()(tx)
-				if err != nil {
-					return err
-				}
-
-				return clientCtx.PrintString(fmt.Sprintf("%s\n", json))
-			}
-
-			if !clientCtx.SkipConfirm {
-				out, err := clientCtx.TxConfig.TxJSONEncoder()(tx)
-				if err != nil {
-					return err
-				}
-
-				_, _ = fmt.Fprintf(os.Stderr, "%s\n\n", out)
-
-				buf := bufio.NewReader(os.Stdin)
-				ok, err := input.GetConfirmation("confirm transaction before signing and broadcasting", buf, os.Stderr)
-
-				if err != nil || !ok {
-					_, _ = fmt.Fprintf(os.Stderr, "%s\n", "canceled transaction")
-					return err
-				}
-			}
-
-			txBytes, err := clientCtx.TxConfig.TxEncoder()(tx)
-			if err != nil {
-				return err
-			}
-
-			// broadcast to a Tendermint node
-			res, err := clientCtx.BroadcastTx(txBytes)
-			if err != nil {
-				return err
-			}
-
-			return clientCtx.PrintProto(res)
-		},
-	}
-
-	flags.AddTxFlagsToCmd(cmd)
-	return cmd
-}
-
 func getCmdSetMappingEvmAddress() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set-mapping-evm [EVM address]",
 		Short: "Set a mapping EVM address for the sender cosmos address",
 		Example: fmt.Sprintf(`
 %[1]s tx %[2]s set-mapping-evm AvSl0d9JrHCW4mdEyHvZu076WxLgH0bBVLigUcFm4UjV --from <key> --gas 1000000
+%[1]s tx %[2]s set-mapping-evm AvSl0d9JrHCW4mdEyHvZu076WxLgH0bBVLigUcFm4UjV --eip712 --generate-only
 `, version.AppName, types.ModuleName,
 		),
 		Args: cobra.ExactArgs(1),
@@ -153,10 +73,19 @@ func getCmdSetMappingEvmAddress() *cobra.Command {
 				return err
 			}
 
+			useEIP712, err := cmd.Flags().GetBool(flagEIP712)
+			if err != nil {
+				return err
+			}
+			if useEIP712 {
+				return generateOrBroadcastEIP712TxCLI(clientCtx, cmd, &msg)
+			}
+
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
 	flags.AddTxFlagsToCmd(cmd)
+	addEIP712Flags(cmd)
 	return cmd
 }
 
@@ -166,6 +95,7 @@ func getCmdDeleteMappingEvmAddress() *cobra.Command {
 		Short: "Delete a mapping EVM address for the sender cosmos address",
 		Example: fmt.Sprintf(`
 %[1]s tx %[2]s delete-mapping-evm --from <key> --gas 1000000
+%[1]s tx %[2]s delete-mapping-evm --eip712 --generate-only
 `, version.AppName, types.ModuleName,
 		),
 		Args: cobra.NoArgs,
@@ -181,9 +111,18 @@ func getCmdDeleteMappingEvmAddress() *cobra.Command {
 				return err
 			}
 
+			useEIP712, err := cmd.Flags().GetBool(flagEIP712)
+			if err != nil {
+				return err
+			}
+			if useEIP712 {
+				return generateOrBroadcastEIP712TxCLI(clientCtx, cmd, &msg)
+			}
+
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
 	flags.AddTxFlagsToCmd(cmd)
+	addEIP712Flags(cmd)
 	return cmd
 }
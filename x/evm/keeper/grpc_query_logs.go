@@ -0,0 +1,189 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// defaultLogsPageLimit caps how many logs a single Logs call returns, same
+// role as a cosmos query.PageRequest limit default.
+const defaultLogsPageLimit = 100
+
+// logPosition is the (block, txIndex, logIndex) a --page-key resumes from.
+type logPosition struct {
+	height   int64
+	txIndex  uint
+	logIndex uint
+}
+
+func (p logPosition) String() string {
+	return fmt.Sprintf("%d-%d-%d", p.height, p.txIndex, p.logIndex)
+}
+
+// before reports whether a log at (txIndex, logIndex) in the same block
+// comes strictly before p, i.e. was already returned by a previous page.
+func (p logPosition) before(txIndex, logIndex uint) bool {
+	if txIndex != p.txIndex {
+		return txIndex < p.txIndex
+	}
+	return logIndex < p.logIndex
+}
+
+func parseLogsPageKey(pageKey string) (logPosition, error) {
+	if pageKey == "" {
+		return logPosition{}, nil
+	}
+
+	parts := strings.Split(pageKey, "-")
+	if len(parts) != 3 {
+		return logPosition{}, fmt.Errorf("malformed page key %q, want height-txIndex-logIndex", pageKey)
+	}
+
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return logPosition{}, fmt.Errorf("malformed page key height %q: %w", parts[0], err)
+	}
+	txIndex, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return logPosition{}, fmt.Errorf("malformed page key txIndex %q: %w", parts[1], err)
+	}
+	logIndex, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return logPosition{}, fmt.Errorf("malformed page key logIndex %q: %w", parts[2], err)
+	}
+
+	return logPosition{height: height, txIndex: uint(txIndex), logIndex: uint(logIndex)}, nil
+}
+
+// parseLogAddresses converts addresses into common.Addresses, the same
+// validation Logs applies before bloom-filtering candidate blocks.
+func parseLogAddresses(addresses []string) ([]common.Address, error) {
+	parsed := make([]common.Address, len(addresses))
+	for i, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid address %q", address)
+		}
+		parsed[i] = common.HexToAddress(address)
+	}
+	return parsed, nil
+}
+
+// parseLogTopics converts topics into eth_getLogs's topic matrix: an empty
+// string at position i means "any topic" at that position.
+func parseLogTopics(topics []string) [][]common.Hash {
+	parsed := make([][]common.Hash, len(topics))
+	for i, topic := range topics {
+		if topic == "" {
+			continue
+		}
+		parsed[i] = []common.Hash{common.HexToHash(topic)}
+	}
+	return parsed
+}
+
+// collectBlockLogs appends blockLogs (all from height) to logs, skipping any
+// already returned by a previous page - i.e. ordered before resumeFrom in
+// the same block - and any that don't match addresses/topics. It stops
+// once logs reaches limit, returning the page key the next call should
+// resume from; the returned key is empty if the page isn't yet full.
+func collectBlockLogs(
+	logs []*ethtypes.Log, blockLogs []*ethtypes.Log, height int64, resumeFrom logPosition,
+	addresses []common.Address, topics [][]common.Hash, limit int,
+) ([]*ethtypes.Log, string) {
+	for _, log := range blockLogs {
+		if height == resumeFrom.height && resumeFrom.before(log.TxIndex, log.Index) {
+			continue
+		}
+		if !logMatches(log, addresses, topics) {
+			continue
+		}
+
+		logs = append(logs, log)
+		if len(logs) == limit {
+			return logs, logPosition{height: height, txIndex: log.TxIndex, logIndex: log.Index + 1}.String()
+		}
+	}
+	return logs, ""
+}
+
+// Logs implements types.QueryServer, matching eth_getLogs semantics: it
+// bloom-filters each candidate block before paying for GetLogsByHeight, then
+// applies the exact address/topic matrix to that block's logs.
+func (k Keeper) Logs(c context.Context, req *types.QueryLogsRequest) (*types.QueryLogsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	resumeFrom, err := parseLogsPageKey(req.PageKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	fromBlock := req.FromBlock
+	if resumeFrom.height > fromBlock {
+		fromBlock = resumeFrom.height
+	}
+	toBlock := req.ToBlock
+	if toBlock == 0 {
+		toBlock = ctx.BlockHeight()
+	}
+	if fromBlock > toBlock {
+		return nil, status.Error(codes.InvalidArgument, "from-block must not be after to-block")
+	}
+
+	addresses, err := parseLogAddresses(req.Addresses)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	topics := parseLogTopics(req.Topics)
+
+	var (
+		logs    []*ethtypes.Log
+		nextKey string
+	)
+	for height := fromBlock; height <= toBlock && len(logs) < defaultLogsPageLimit; height++ {
+		if !bloomMatches(k.GetBlockBloom(ctx, height), addresses, topics) {
+			continue
+		}
+
+		blockLogs, err := k.GetLogsByHeight(ctx, height)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		var key string
+		logs, key = collectBlockLogs(logs, blockLogs, height, resumeFrom, addresses, topics, defaultLogsPageLimit)
+		if key != "" {
+			nextKey = key
+			break
+		}
+	}
+
+	return &types.QueryLogsResponse{Logs: logs, NextPageKey: nextKey}, nil
+}
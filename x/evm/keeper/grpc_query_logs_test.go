@@ -0,0 +1,144 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+func TestParseLogsPageKeyRoundTrip(t *testing.T) {
+	want := logPosition{height: 42, txIndex: 3, logIndex: 7}
+
+	got, err := parseLogsPageKey(want.String())
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestParseLogsPageKeyEmptyIsZeroValue(t *testing.T) {
+	got, err := parseLogsPageKey("")
+	require.NoError(t, err)
+	require.Equal(t, logPosition{}, got)
+}
+
+func TestParseLogsPageKeyRejectsMalformedInput(t *testing.T) {
+	_, err := parseLogsPageKey("not-a-valid-key")
+	require.Error(t, err)
+}
+
+func TestLogPositionBeforeOrdersByTxIndexThenLogIndex(t *testing.T) {
+	p := logPosition{height: 10, txIndex: 2, logIndex: 5}
+
+	require.True(t, p.before(1, 0), "earlier txIndex is before p regardless of logIndex")
+	require.True(t, p.before(2, 4), "same txIndex, earlier logIndex is before p")
+	require.False(t, p.before(2, 5), "p itself is not before p")
+	require.False(t, p.before(2, 6), "later logIndex in the same tx is not before p")
+	require.False(t, p.before(3, 0), "later txIndex is not before p")
+}
+
+func TestParseLogAddressesRejectsInvalidAddress(t *testing.T) {
+	_, err := parseLogAddresses([]string{addrA.Hex(), "not-an-address"})
+	require.Error(t, err)
+}
+
+func TestParseLogAddressesPreservesOrder(t *testing.T) {
+	got, err := parseLogAddresses([]string{addrB.Hex(), addrA.Hex()})
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{addrB, addrA}, got)
+}
+
+func TestParseLogTopicsEmptyStringIsWildcard(t *testing.T) {
+	got := parseLogTopics([]string{topicA.Hex(), ""})
+	require.Equal(t, [][]common.Hash{{topicA}, nil}, got)
+}
+
+// TestCollectBlockLogsPaginatesAcrossBlocks drives collectBlockLogs the way
+// Logs does across two synthetic blocks, checking that: a page fills
+// exactly at limit and returns a resume key; a log ordered before that key
+// (i.e. already returned) is skipped on a later call with the same height,
+// while the log at the key itself is returned; and address/topic filtering
+// still applies while paginating.
+func TestCollectBlockLogsPaginatesAcrossBlocks(t *testing.T) {
+	block1Logs := []*ethtypes.Log{
+		{Address: addrA, TxIndex: 0, Index: 0},
+		{Address: addrA, TxIndex: 0, Index: 1},
+		{Address: addrB, TxIndex: 1, Index: 0}, // filtered out by address
+	}
+	block2Logs := []*ethtypes.Log{
+		{Address: addrA, TxIndex: 0, Index: 0},
+	}
+
+	var logs []*ethtypes.Log
+	var nextKey string
+
+	logs, nextKey = collectBlockLogs(logs, block1Logs, 1, logPosition{}, []common.Address{addrA}, nil, 1)
+	require.Len(t, logs, 1, "page fills at the limit, stopping mid-block")
+	require.Equal(t, logPosition{height: 1, txIndex: 0, logIndex: 1}.String(), nextKey)
+
+	resumeFrom, err := parseLogsPageKey(nextKey)
+	require.NoError(t, err)
+
+	logs, nextKey = collectBlockLogs(logs, block1Logs, 1, resumeFrom, []common.Address{addrA}, nil, 100)
+	require.Len(t, logs, 2, "the previously-returned log at (0,0) must not be returned again")
+	require.Empty(t, nextKey, "page isn't full, so there's nothing left to resume from")
+
+	logs, nextKey = collectBlockLogs(logs, block2Logs, 2, resumeFrom, []common.Address{addrA}, nil, 100)
+	require.Len(t, logs, 3, "a later block's logs are unaffected by a resume key from an earlier block")
+	require.Empty(t, nextKey)
+}
+
+func TestLogsRejectsNilRequest(t *testing.T) {
+	_, err := Keeper{}.Logs(context.Background(), nil)
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// TestLogsRejectsFromBlockAfterToBlock exercises Logs's from/to ordering
+// check, which - like the nil-request check - runs before anything that
+// needs a real statedb-backed Keeper (see grpc_query_reads_test.go for why
+// that's where this package's Keeper-method coverage stops). Left
+// unverified by every Logs test in this file: k.GetBlockBloom and
+// k.GetLogsByHeight themselves, i.e. whether Logs's per-block loop actually
+// bloom-filters and paginates against real chain data - only the pure
+// collectBlockLogs/parseLogAddresses/parseLogTopics helpers it delegates to
+// are covered directly above.
+func TestLogsRejectsFromBlockAfterToBlock(t *testing.T) {
+	_, err := Keeper{}.Logs(sdkWrapContext(), &types.QueryLogsRequest{FromBlock: 10, ToBlock: 5})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestLogsRejectsInvalidAddress(t *testing.T) {
+	_, err := Keeper{}.Logs(sdkWrapContext(), &types.QueryLogsRequest{
+		FromBlock: 1, ToBlock: 1, Addresses: []string{"not-an-address"},
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestLogsRejectsMalformedPageKey(t *testing.T) {
+	_, err := Keeper{}.Logs(sdkWrapContext(), &types.QueryLogsRequest{PageKey: "not-a-valid-key"})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// MappedEvmAddress implements types.QueryServer. It is NOT reachable over
+// REST/grpc-gateway: that requires a google.api.http annotation on the
+// Query.MappedEvmAddress rpc in proto/ethermint/evm/v1/query.proto, plus the
+// generated query.pb.gw.go mux registration - and this tree has no proto/
+// directory or any generated gateway code at all to extend. Only the gRPC
+// and AutoCLI-generated CLI paths are implemented here; gateway routes are a
+// real gap against the original request, not a completed deliverable.
+func (k Keeper) MappedEvmAddress(
+	c context.Context, req *types.QueryMappedEvmAddressRequest,
+) (*types.QueryMappedEvmAddressResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	evmAddress, found := k.GetMappedEvmAddress(ctx, req.CosmosAddress)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no evm address mapped to %s", req.CosmosAddress)
+	}
+
+	return &types.QueryMappedEvmAddressResponse{EvmAddress: evmAddress}, nil
+}
+
+// MappedCosmosAddress implements types.QueryServer, resolving via the
+// reverse (evm->cosmos) index so it doesn't scan the forward index.
+func (k Keeper) MappedCosmosAddress(
+	c context.Context, req *types.QueryMappedCosmosAddressRequest,
+) (*types.QueryMappedCosmosAddressResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	cosmosAddress, found := k.GetMappedCosmosAddress(ctx, req.EvmAddress)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no cosmos address mapped to %s", req.EvmAddress)
+	}
+
+	return &types.QueryMappedCosmosAddressResponse{CosmosAddress: cosmosAddress}, nil
+}
+
+// MappedEvmAddresses implements types.QueryServer, paginating over the
+// forward index.
+func (k Keeper) MappedEvmAddresses(
+	c context.Context, req *types.QueryMappedEvmAddressesRequest,
+) (*types.QueryMappedEvmAddressesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	mappings, pageRes, err := k.IterateMappings(ctx, req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryMappedEvmAddressesResponse{Mappings: mappings, Pagination: pageRes}, nil
+}
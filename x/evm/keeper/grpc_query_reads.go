@@ -0,0 +1,61 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// Balance implements types.QueryServer, reading straight from the statedb
+// balance the EVM itself uses rather than going through the JSON-RPC layer.
+func (k Keeper) Balance(c context.Context, req *types.QueryBalanceRequest) (*types.QueryBalanceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	if !common.IsHexAddress(req.Address) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %q", req.Address)
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	balance := k.GetBalance(ctx, common.HexToAddress(req.Address))
+
+	return &types.QueryBalanceResponse{Balance: balance.String()}, nil
+}
+
+// Nonce implements types.QueryServer. req.Pending is not honored: the query
+// server only ever sees last-committed state, and the mempool's CheckTx
+// state isn't reachable from here, so --pending currently returns the same
+// result as a committed-state query.
+func (k Keeper) Nonce(c context.Context, req *types.QueryNonceRequest) (*types.QueryNonceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	if !common.IsHexAddress(req.Address) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %q", req.Address)
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	nonce := k.GetNonce(ctx, common.HexToAddress(req.Address))
+
+	return &types.QueryNonceResponse{Nonce: nonce}, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import "strings"
+
+// Address mapping is kept under two prefixes so both directions of the
+// set-mapping-evm/delete-mapping-evm relationship resolve with a single
+// store read instead of a full scan:
+//   - KeyPrefixCosmosToEvm is the forward index, keyed by cosmos address.
+//   - KeyPrefixEvmToCosmos is the reverse index, keyed by lowercase hex EVM
+//     address, and lets MappedCosmosAddress resolve without depending on the
+//     caller's checksum casing.
+var (
+	KeyPrefixCosmosToEvm = []byte{0x01}
+	KeyPrefixEvmToCosmos = []byte{0x02}
+)
+
+// CosmosToEvmKey returns the forward-index store key for cosmosAddress.
+func CosmosToEvmKey(cosmosAddress string) []byte {
+	return append(KeyPrefixCosmosToEvm, []byte(cosmosAddress)...)
+}
+
+// EvmToCosmosKey returns the reverse-index store key for evmAddress.
+func EvmToCosmosKey(evmAddress string) []byte {
+	return append(KeyPrefixEvmToCosmos, []byte(strings.ToLower(evmAddress))...)
+}
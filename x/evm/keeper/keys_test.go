@@ -0,0 +1,38 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/ethermint/x/evm/keeper"
+)
+
+func TestCosmosToEvmKeyEvmToCosmosKeyDistinctPrefixes(t *testing.T) {
+	cosmosKey := keeper.CosmosToEvmKey("orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv")
+	evmKey := keeper.EvmToCosmosKey("0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E")
+
+	require.NotEqual(t, cosmosKey[0], evmKey[0], "forward and reverse indexes must not collide in the same store")
+}
+
+func TestEvmToCosmosKeyIsCaseInsensitive(t *testing.T) {
+	lower := keeper.EvmToCosmosKey("0x7cb61d4117ae31a12e393a1cfa3bac666481d02e")
+	mixedCase := keeper.EvmToCosmosKey("0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E")
+
+	require.Equal(t, lower, mixedCase, "reverse lookups must not depend on the caller's checksum casing")
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// bloomMatches reports whether a block's bloom filter could contain logs
+// matching addresses/topics, mirroring go-ethereum's eth/filters bloom
+// pre-check: an empty addresses (or topics[i]) list is a wildcard, otherwise
+// at least one candidate per position must be present in the bloom. It can
+// false-positive (that's what bloom filters do) but never false-negative, so
+// GetLogsByHeight is only worth calling when this returns true.
+func bloomMatches(bloom ethtypes.Bloom, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		found := false
+		for _, addr := range addresses {
+			if ethtypes.BloomLookup(bloom, addr) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, positionTopics := range topics {
+		if len(positionTopics) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range positionTopics {
+			if ethtypes.BloomLookup(bloom, topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// logMatches reports whether log satisfies the address/topic matrix, with
+// the same eth_getLogs semantics as bloomMatches's pre-check: addresses is an
+// OR list, topics is position-wise ANDed OR lists, and an empty list at any
+// position is a wildcard for that position.
+func logMatches(log *ethtypes.Log, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		matched := false
+		for _, addr := range addresses {
+			if addr == log.Address {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+	for i, positionTopics := range topics {
+		if len(positionTopics) == 0 {
+			continue
+		}
+		matched := false
+		for _, topic := range positionTopics {
+			if topic == log.Topics[i] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
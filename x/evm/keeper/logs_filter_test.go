@@ -0,0 +1,73 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addrA   = common.HexToAddress("0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E")
+	addrB   = common.HexToAddress("0x0000000000000000000000000000000000dEaD")
+	topicA  = common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	topicB  = common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	sampleL = &ethtypes.Log{Address: addrA, Topics: []common.Hash{topicA, topicB}}
+)
+
+func newBloomFor(addr common.Address, topics ...common.Hash) ethtypes.Bloom {
+	var bloom ethtypes.Bloom
+	bloom.Add(addr.Bytes())
+	for _, topic := range topics {
+		bloom.Add(topic.Bytes())
+	}
+	return bloom
+}
+
+func TestBloomMatchesWildcardsOnEmptyFilters(t *testing.T) {
+	bloom := newBloomFor(addrA, topicA)
+	require.True(t, bloomMatches(bloom, nil, nil))
+}
+
+func TestBloomMatchesRequiresEveryPosition(t *testing.T) {
+	bloom := newBloomFor(addrA, topicA)
+
+	require.True(t, bloomMatches(bloom, []common.Address{addrA}, [][]common.Hash{{topicA}}))
+	require.False(t, bloomMatches(bloom, []common.Address{addrB}, nil), "bloom doesn't contain addrB")
+	require.False(t, bloomMatches(bloom, nil, [][]common.Hash{{topicB}}), "bloom doesn't contain topicB")
+}
+
+func TestBloomMatchesOrsWithinAPosition(t *testing.T) {
+	bloom := newBloomFor(addrA, topicA)
+	require.True(t, bloomMatches(bloom, []common.Address{addrB, addrA}, nil), "addrA is one of the OR candidates")
+}
+
+func TestLogMatchesAddressAndPositionalTopics(t *testing.T) {
+	require.True(t, logMatches(sampleL, nil, nil), "no filter wildcards everything")
+	require.True(t, logMatches(sampleL, []common.Address{addrA}, nil))
+	require.False(t, logMatches(sampleL, []common.Address{addrB}, nil))
+
+	require.True(t, logMatches(sampleL, nil, [][]common.Hash{{topicA}, {topicB}}))
+	require.False(t, logMatches(sampleL, nil, [][]common.Hash{{topicB}, {topicA}}), "topics are position-specific")
+	require.True(t, logMatches(sampleL, nil, [][]common.Hash{{}, {topicB}}), "empty position is a wildcard")
+}
+
+func TestLogMatchesRejectsMoreTopicsThanLogHas(t *testing.T) {
+	require.False(t, logMatches(sampleL, nil, [][]common.Hash{{topicA}, {topicB}, {topicA}}))
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// SetMapping records cosmosAddress <-> evmAddress in both the forward and
+// reverse indexes, overwriting any mapping cosmosAddress previously held so
+// the reverse index never points at a stale cosmos address. It rejects the
+// write if evmAddress is already reverse-mapped to a different cosmos
+// address: without this check, the reverse index would silently flip to the
+// new caller while the old owner's forward entry keeps reporting the same
+// evmAddress, leaving the two indexes inconsistent.
+func (k Keeper) SetMapping(ctx sdk.Context, cosmosAddress, evmAddress string) error {
+	if existingOwner, found := k.GetMappedCosmosAddress(ctx, evmAddress); conflictingMapping(cosmosAddress, existingOwner, found) {
+		return sdkerrors.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"evm address %s is already mapped to cosmos address %s", evmAddress, existingOwner,
+		)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+
+	if previousEvmAddress, found := k.GetMappedEvmAddress(ctx, cosmosAddress); found {
+		store.Delete(EvmToCosmosKey(previousEvmAddress))
+	}
+
+	store.Set(CosmosToEvmKey(cosmosAddress), []byte(evmAddress))
+	store.Set(EvmToCosmosKey(evmAddress), []byte(cosmosAddress))
+	return nil
+}
+
+// conflictingMapping reports whether evmAddress's existing reverse mapping
+// belongs to a cosmos address other than cosmosAddress, i.e. whether
+// SetMapping(cosmosAddress, evmAddress) would reassign someone else's
+// binding. Re-setting a cosmos address's own existing mapping is not a
+// conflict.
+func conflictingMapping(cosmosAddress, existingOwner string, found bool) bool {
+	return found && existingOwner != cosmosAddress
+}
+
+// DeleteMapping removes cosmosAddress's mapping from both indexes. It is a
+// no-op if cosmosAddress has no mapping.
+func (k Keeper) DeleteMapping(ctx sdk.Context, cosmosAddress string) {
+	evmAddress, found := k.GetMappedEvmAddress(ctx, cosmosAddress)
+	if !found {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(CosmosToEvmKey(cosmosAddress))
+	store.Delete(EvmToCosmosKey(evmAddress))
+}
+
+// GetMappedEvmAddress returns the EVM address mapped to cosmosAddress, via
+// the forward index.
+func (k Keeper) GetMappedEvmAddress(ctx sdk.Context, cosmosAddress string) (string, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(CosmosToEvmKey(cosmosAddress))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// GetMappedCosmosAddress returns the cosmos address mapped to evmAddress, via
+// the reverse index, without scanning the forward index.
+func (k Keeper) GetMappedCosmosAddress(ctx sdk.Context, evmAddress string) (string, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(EvmToCosmosKey(evmAddress))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// IterateCosmosToEvm walks every entry in the forward index in key order,
+// stopping early if cb returns true. It is unpaginated and is meant for
+// migrations, not query handlers.
+func (k Keeper) IterateCosmosToEvm(ctx sdk.Context, cb func(cosmosAddress, evmAddress string) (stop bool)) {
+	mappingStore := prefix.NewStore(ctx.KVStore(k.storeKey), KeyPrefixCosmosToEvm)
+
+	iterator := mappingStore.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(string(iterator.Key()), string(iterator.Value())) {
+			return
+		}
+	}
+}
+
+// IterateMappings paginates over the forward index for the list-mappings
+// query, so large mapping tables don't need to be loaded in full.
+func (k Keeper) IterateMappings(
+	ctx sdk.Context, pageReq *query.PageRequest,
+) ([]types.AddressMapping, *query.PageResponse, error) {
+	mappingStore := prefix.NewStore(ctx.KVStore(k.storeKey), KeyPrefixCosmosToEvm)
+
+	var mappings []types.AddressMapping
+	pageRes, err := query.Paginate(mappingStore, pageReq, func(key, value []byte) error {
+		mappings = append(mappings, types.AddressMapping{
+			CosmosAddress: string(key),
+			EvmAddress:    string(value),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mappings, pageRes, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestConflictingMapping exercises the rule SetMapping applies to reject a
+// reassignment of someone else's evmAddress: setting cosmosAddress is only
+// a conflict if evmAddress's existing reverse mapping belongs to a
+// different cosmos address.
+//
+// This is NOT full coverage of SetMapping/DeleteMapping/IterateMappings:
+// this tree has no `type Keeper struct` or NewKeeper anywhere (grep finds
+// none), only a `k Keeper` receiver assumed to exist externally, so there
+// is no way to construct a KVStore-backed Keeper to write through and read
+// back. Left unverified here: that SetMapping's two store.Set calls and
+// DeleteMapping's two store.Delete calls actually keep the forward and
+// reverse indexes consistent on a real store, and IterateMappings'
+// pagination (limit/offset/page-key, partial pages, empty results). A
+// Keeper test suite outside this slice needs to add those before this is
+// real coverage of the mapping store; conflictingMapping is as much of the
+// rule as can be pulled out into something testable without one.
+func TestConflictingMapping(t *testing.T) {
+	const (
+		cosmosAddress = "orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv"
+		otherCosmos   = "orai1p5yxut8sv2ceqar2c9gf9rhjesd2qj5d939kkc"
+	)
+
+	require.False(t, conflictingMapping(cosmosAddress, "", false),
+		"evmAddress with no existing owner is never a conflict")
+	require.False(t, conflictingMapping(cosmosAddress, cosmosAddress, true),
+		"re-setting a cosmos address's own existing mapping is not a conflict")
+	require.True(t, conflictingMapping(cosmosAddress, otherCosmos, true),
+		"reassigning an evmAddress already owned by a different cosmos address is a conflict")
+}
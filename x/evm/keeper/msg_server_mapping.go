@@ -0,0 +1,74 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// SetMappingEvmAddress implements types.MsgServer, recording the mapping in
+// both the forward and reverse indexes so MappedCosmosAddress resolves the
+// signer back out of the EVM address it just set.
+func (k Keeper) SetMappingEvmAddress(
+	goCtx context.Context, msg *types.MsgSetMappingEvmAddress,
+) (*types.MsgSetMappingEvmAddressResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if err := k.SetMapping(ctx, msg.CosmosAddress, msg.EvmAddress); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSetMappingEvmAddress,
+			sdk.NewAttribute(types.AttributeKeyCosmosAddress, msg.CosmosAddress),
+			sdk.NewAttribute(types.AttributeKeyEvmAddress, msg.EvmAddress),
+		),
+	)
+
+	return &types.MsgSetMappingEvmAddressResponse{}, nil
+}
+
+// DeleteMappingEvmAddress implements types.MsgServer, removing the signer's
+// mapping from both indexes.
+func (k Keeper) DeleteMappingEvmAddress(
+	goCtx context.Context, msg *types.MsgDeleteMappingEvmAddress,
+) (*types.MsgDeleteMappingEvmAddressResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	k.DeleteMapping(ctx, msg.CosmosAddress)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDeleteMappingEvmAddress,
+			sdk.NewAttribute(types.AttributeKeyCosmosAddress, msg.CosmosAddress),
+		),
+	)
+
+	return &types.MsgDeleteMappingEvmAddressResponse{}, nil
+}
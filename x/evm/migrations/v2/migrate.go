@@ -0,0 +1,64 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+// Package v2 migrates x/evm state to add the evm->cosmos reverse address
+// index introduced alongside the MappedCosmosAddress query, so the index
+// covers mappings set before this migration ships.
+package v2
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MappingKeeper is the subset of x/evm/keeper.Keeper this migration needs.
+// It is declared separately (rather than depending on x/evm/keeper directly)
+// to keep the migration package importable without pulling in the full evm
+// keeper's dependency tree, matching how other Cosmos SDK module migrations
+// declare their own narrow keeper interface.
+type MappingKeeper interface {
+	IterateCosmosToEvm(ctx sdk.Context, cb func(cosmosAddress, evmAddress string) (stop bool))
+	SetMapping(ctx sdk.Context, cosmosAddress, evmAddress string) error
+}
+
+// mapping is one forward-index entry collected from the pre-existing
+// cosmos->evm store before any reverse-index writes happen.
+type mapping struct {
+	cosmosAddress string
+	evmAddress    string
+}
+
+// MigrateStore backfills the evm->cosmos reverse index from the pre-existing
+// cosmos->evm forward index. It collects every forward-index entry first and
+// only then writes the reverse index, rather than calling SetMapping from
+// inside the IterateCosmosToEvm callback: mutating the same underlying
+// KVStore while an iterator over it is still open is unsafe on typical
+// Cosmos SDK store backends. SetMapping is reused for the write (rather than
+// setting the reverse key directly) so the backfilled entries stay
+// byte-for-byte consistent with ones written post-migration.
+func MigrateStore(ctx sdk.Context, k MappingKeeper) error {
+	var mappings []mapping
+	k.IterateCosmosToEvm(ctx, func(cosmosAddress, evmAddress string) bool {
+		mappings = append(mappings, mapping{cosmosAddress: cosmosAddress, evmAddress: evmAddress})
+		return false
+	})
+
+	for _, m := range mappings {
+		if err := k.SetMapping(ctx, m.cosmosAddress, m.evmAddress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package v2_test
+
+import (
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	v2 "github.com/evmos/ethermint/x/evm/migrations/v2"
+)
+
+// fakeMappingKeeper is a map-backed stand-in for x/evm/keeper.Keeper, since
+// the real keeper's store requires a full app context this package doesn't
+// have access to. iterating is set while IterateCosmosToEvm's callback is
+// running, and SetMapping returns an error if it is called then, which is
+// what TestMigrateStoreDoesNotWriteDuringIteration below relies on.
+type fakeMappingKeeper struct {
+	forward   map[string]string
+	reverse   map[string]string
+	iterating bool
+}
+
+func (k *fakeMappingKeeper) IterateCosmosToEvm(_ sdk.Context, cb func(cosmosAddress, evmAddress string) bool) {
+	k.iterating = true
+	defer func() { k.iterating = false }()
+
+	for cosmosAddress, evmAddress := range k.forward {
+		if cb(cosmosAddress, evmAddress) {
+			return
+		}
+	}
+}
+
+func (k *fakeMappingKeeper) SetMapping(_ sdk.Context, cosmosAddress, evmAddress string) error {
+	if k.iterating {
+		return errors.New("SetMapping must not be called while IterateCosmosToEvm is iterating")
+	}
+	k.reverse[evmAddress] = cosmosAddress
+	return nil
+}
+
+func TestMigrateStoreBackfillsReverseIndex(t *testing.T) {
+	const (
+		cosmosAddress = "orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv"
+		evmAddress    = "0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E"
+	)
+
+	k := &fakeMappingKeeper{
+		forward: map[string]string{cosmosAddress: evmAddress},
+		reverse: map[string]string{},
+	}
+
+	require.NoError(t, v2.MigrateStore(sdk.Context{}, k))
+	require.Equal(t, cosmosAddress, k.reverse[evmAddress])
+}
+
+// TestMigrateStoreDoesNotWriteDuringIteration checks that MigrateStore
+// collects every forward-index entry before writing any reverse-index
+// entry, for several mappings at once - guarding against a regression back
+// to writing from inside the IterateCosmosToEvm callback, which fakeMappingKeeper
+// now flags as an error via its iterating guard.
+func TestMigrateStoreDoesNotWriteDuringIteration(t *testing.T) {
+	k := &fakeMappingKeeper{
+		forward: map[string]string{
+			"orai1knzg7jdc49ghnc2pkqg6vks8ccsk6efzfgv6gv": "0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E",
+			"orai1p5yxut8sv2ceqar2c9gf9rhjesd2qj5d939kkc": "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC",
+		},
+		reverse: map[string]string{},
+	}
+
+	require.NoError(t, v2.MigrateStore(sdk.Context{}, k))
+	require.Len(t, k.reverse, 2)
+}
+
+func TestMigrateStoreNoOpOnEmptyForwardIndex(t *testing.T) {
+	k := &fakeMappingKeeper{forward: map[string]string{}, reverse: map[string]string{}}
+
+	require.NoError(t, v2.MigrateStore(sdk.Context{}, k))
+	require.Empty(t, k.reverse)
+}